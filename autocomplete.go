@@ -2,10 +2,12 @@ package goplaces
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
+	"unicode/utf8"
 )
 
 const autocompleteFieldMask = "suggestions.placePrediction.placeId,suggestions.placePrediction.place,suggestions.placePrediction.text,suggestions.placePrediction.structuredFormat,suggestions.placePrediction.types,suggestions.placePrediction.distanceMeters,suggestions.queryPrediction.text,suggestions.queryPrediction.structuredFormat"
@@ -16,22 +18,17 @@ func (c *Client) Autocomplete(ctx context.Context, req AutocompleteRequest) (Aut
 	if err := validateAutocompleteRequest(req); err != nil {
 		return AutocompleteResponse{}, err
 	}
-
-	body := map[string]any{
-		"input": strings.TrimSpace(req.Input),
-	}
-	if strings.TrimSpace(req.SessionToken) != "" {
-		body["sessionToken"] = strings.TrimSpace(req.SessionToken)
-	}
-	if strings.TrimSpace(req.Language) != "" {
-		body["languageCode"] = strings.TrimSpace(req.Language)
-	}
-	if strings.TrimSpace(req.Region) != "" {
-		body["regionCode"] = strings.TrimSpace(req.Region)
+	language, region, err := c.locale(req.Language, req.Region)
+	if err != nil {
+		return AutocompleteResponse{}, err
 	}
-	if req.LocationBias != nil {
-		body["locationBias"] = circlePayload(req.LocationBias)
+	req.Language, req.Region = language, region
+
+	sessionToken := strings.TrimSpace(req.SessionToken)
+	if sessionToken == "" && c.autoSessionToken {
+		sessionToken = NewAutocompleteSession()
 	}
+	body := buildAutocompleteBody(req, sessionToken)
 
 	endpoint, err := c.buildURL("/places:autocomplete", nil)
 	if err != nil {
@@ -60,7 +57,46 @@ func (c *Client) Autocomplete(ctx context.Context, req AutocompleteRequest) (Aut
 		suggestions = suggestions[:req.Limit]
 	}
 
-	return AutocompleteResponse{Suggestions: suggestions}, nil
+	return AutocompleteResponse{Suggestions: suggestions, SessionToken: sessionToken}, nil
+}
+
+func buildAutocompleteBody(req AutocompleteRequest, sessionToken string) map[string]any {
+	body := map[string]any{
+		"input": strings.TrimSpace(req.Input),
+	}
+	if sessionToken != "" {
+		body["sessionToken"] = sessionToken
+	}
+	if strings.TrimSpace(req.Language) != "" {
+		body["languageCode"] = strings.TrimSpace(req.Language)
+	}
+	if strings.TrimSpace(req.Region) != "" {
+		body["regionCode"] = strings.TrimSpace(req.Region)
+	}
+	if req.LocationBias != nil {
+		body["locationBias"] = locationBiasPayload(req.LocationBias)
+	}
+	if len(req.IncludedPrimaryTypes) > 0 {
+		body["includedPrimaryTypes"] = req.IncludedPrimaryTypes
+	}
+	if req.InputOffset != nil {
+		body["inputOffset"] = *req.InputOffset
+	}
+	if req.Origin != nil {
+		body["origin"] = latLngPayload(*req.Origin)
+	}
+	return body
+}
+
+// NewAutocompleteSession returns a random UUID v4 for
+// AutocompleteRequest.SessionToken.
+func NewAutocompleteSession() string {
+	var uuid [16]byte
+	// crypto/rand.Read never returns an error.
+	_, _ = rand.Read(uuid[:])
+	uuid[6] = uuid[6]&0x0f | 0x40 // version 4
+	uuid[8] = uuid[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:])
 }
 
 type autocompleteResponsePayload struct {
@@ -154,10 +190,21 @@ func validateAutocompleteRequest(req AutocompleteRequest) error {
 	if req.Limit < 1 || req.Limit > maxAutocompleteLimit {
 		return ValidationError{Field: "limit", Message: fmt.Sprintf("must be 1-%d", maxAutocompleteLimit)}
 	}
+	if len(req.IncludedPrimaryTypes) > maxAutocompleteTypes {
+		return ValidationError{Field: "included_primary_types", Message: fmt.Sprintf("at most %d", maxAutocompleteTypes)}
+	}
+	if req.InputOffset != nil {
+		if length := utf8.RuneCountInString(strings.TrimSpace(req.Input)); *req.InputOffset < 0 || *req.InputOffset > length {
+			return ValidationError{Field: "input_offset", Message: fmt.Sprintf("must be 0-%d", length)}
+		}
+	}
 	if req.LocationBias != nil {
 		if err := validateLocationBias(req.LocationBias); err != nil {
 			return err
 		}
 	}
+	if req.Origin != nil {
+		return validateLatLng("origin", *req.Origin)
+	}
 	return nil
 }
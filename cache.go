@@ -0,0 +1,149 @@
+package goplaces
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errNotModified reports a 304 to an If-None-Match revalidation.
+var errNotModified = errors.New("goplaces: not modified")
+
+// Cache stores API response bodies for Options.CacheTTL. Implementations
+// must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry)
+}
+
+// CacheEntry is a cached response body. Entries past Expires are still
+// returned by Get so their ETag can be revalidated.
+type CacheEntry struct {
+	Body    []byte
+	ETag    string
+	Expires time.Time
+}
+
+// defaultCacheEntries bounds NewMemoryCache.
+const defaultCacheEntries = 1000
+
+// NewMemoryCache returns an in-process Cache holding up to 1000 entries.
+func NewMemoryCache() Cache {
+	return NewLRUCache(defaultCacheEntries)
+}
+
+// NewLRUCache returns an in-process Cache that evicts the least recently
+// used entry once it holds capacity entries (minimum 1).
+func NewLRUCache(capacity int) Cache {
+	return &memoryCache{
+		capacity: max(capacity, 1),
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+type memoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	// order holds *memoryItem, most recently used first.
+	order *list.List
+}
+
+type memoryItem struct {
+	key   string
+	entry CacheEntry
+}
+
+func (m *memoryCache) Get(key string) (CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	element, ok := m.entries[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	m.order.MoveToFront(element)
+	return element.Value.(*memoryItem).entry, true
+}
+
+func (m *memoryCache) Set(key string, entry CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if element, ok := m.entries[key]; ok {
+		element.Value.(*memoryItem).entry = entry
+		m.order.MoveToFront(element)
+		return
+	}
+	m.entries[key] = m.order.PushFront(&memoryItem{key: key, entry: entry})
+	if m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryItem).key)
+	}
+}
+
+// cachedGet serves a GET from the cache while fresh. Stale entries with an
+// ETag are revalidated with If-None-Match, and a 304 renews them.
+func (c *Client) cachedGet(ctx context.Context, key, endpoint, fieldMask string) ([]byte, error) {
+	if c.cache == nil {
+		return c.doRequest(ctx, http.MethodGet, endpoint, nil, fieldMask)
+	}
+	entry, found := c.cache.Get(key)
+	if found && time.Now().Before(entry.Expires) {
+		return entry.Body, nil
+	}
+	payload, etag, err := c.doConditionalRequest(ctx, http.MethodGet, endpoint, nil, fieldMask, entry.ETag)
+	if errors.Is(err, errNotModified) && found {
+		payload, etag, err = entry.Body, entry.ETag, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.cacheStore(key, payload, etag)
+	return payload, nil
+}
+
+// cachedBody returns a fresh cached body for key, if any.
+func (c *Client) cachedBody(key string) ([]byte, bool) {
+	if c.cache == nil || key == "" {
+		return nil, false
+	}
+	entry, ok := c.cache.Get(key)
+	if !ok || !time.Now().Before(entry.Expires) {
+		return nil, false
+	}
+	return entry.Body, true
+}
+
+func (c *Client) cacheStore(key string, body []byte, etag string) {
+	if c.cache == nil || key == "" {
+		return
+	}
+	c.cache.Set(key, CacheEntry{Body: body, ETag: etag, Expires: time.Now().Add(c.cacheTTL)})
+}
+
+// searchCacheKey derives a stable key for a text search request.
+func (c *Client) searchCacheKey(req SearchRequest, fieldMask string) string {
+	body := buildSearchBody(req)
+	if c.normalizeQueries {
+		// Only the key is normalized; buildSearchBody keeps textQuery verbatim.
+		if query, ok := body["textQuery"].(string); ok {
+			body["textQuery"] = normalizeQueryKey(query)
+		}
+	}
+	// encoding/json sorts map keys, so equal bodies produce equal keys.
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return ""
+	}
+	return "searchText|" + fieldMask + "|" + string(payload)
+}
+
+func normalizeQueryKey(query string) string {
+	return strings.ToLower(strings.Join(strings.Fields(query), " "))
+}
@@ -0,0 +1,179 @@
+package goplaces
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNormalizeQueryKey(t *testing.T) {
+	if got := normalizeQueryKey("  Coffee   Shop\tNear  ME "); got != "coffee shop near me" {
+		t.Fatalf("unexpected normalized query: %q", got)
+	}
+	if got := normalizeQueryKey(""); got != "" {
+		t.Fatalf("expected empty key, got %q", got)
+	}
+}
+
+func TestSearchCacheKeyNormalizesQueries(t *testing.T) {
+	client := NewClient(Options{APIKey: "test-key", NormalizeQueries: true})
+	a := client.searchCacheKey(SearchRequest{Query: "Coffee  Shop", Limit: 5}, searchFieldMask)
+	b := client.searchCacheKey(SearchRequest{Query: " coffee shop ", Limit: 5}, searchFieldMask)
+	if a == "" || a != b {
+		t.Fatalf("expected equal keys, got %q and %q", a, b)
+	}
+	c := client.searchCacheKey(SearchRequest{Query: "coffee shop", Limit: 6}, searchFieldMask)
+	if a == c {
+		t.Fatalf("expected limit to change key")
+	}
+}
+
+func TestSearchCacheKeyVerbatimByDefault(t *testing.T) {
+	client := NewClient(Options{APIKey: "test-key"})
+	a := client.searchCacheKey(SearchRequest{Query: "Coffee Shop"}, searchFieldMask)
+	b := client.searchCacheKey(SearchRequest{Query: "coffee shop"}, searchFieldMask)
+	if a == b {
+		t.Fatalf("expected distinct keys without normalization")
+	}
+}
+
+func TestSearchCacheKeyKeepsSentQuery(t *testing.T) {
+	request := SearchRequest{Query: "  Coffee  Shop "}
+	client := NewClient(Options{APIKey: "test-key", NormalizeQueries: true})
+	_ = client.searchCacheKey(request, searchFieldMask)
+	if got := buildSearchBody(request)["textQuery"]; got != "  Coffee  Shop " {
+		t.Fatalf("textQuery should stay verbatim, got %#v", got)
+	}
+}
+
+func TestDetailsCacheTTL(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"id": "abc", "displayName": {"text": "Cafe"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, CacheTTL: time.Hour})
+	for range 2 {
+		if _, err := client.Details(context.Background(), "abc"); err != nil {
+			t.Fatalf("details error: %v", err)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected one request within the TTL, got %d", calls.Load())
+	}
+	// A different mask is a different entry.
+	if _, err := client.DetailsWithOptions(context.Background(), DetailsRequest{PlaceID: "abc", IncludeReviews: true}); err != nil {
+		t.Fatalf("details error: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected a request for a new field mask, got %d", calls.Load())
+	}
+	// So is a different locale.
+	if _, err := client.DetailsWithOptions(context.Background(), DetailsRequest{PlaceID: "abc", Language: "de"}); err != nil {
+		t.Fatalf("details error: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected a request for a new language, got %d", calls.Load())
+	}
+
+	expired := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, CacheTTL: time.Nanosecond})
+	for range 2 {
+		if _, err := expired.Details(context.Background(), "abc"); err != nil {
+			t.Fatalf("details error: %v", err)
+		}
+	}
+	if calls.Load() != 5 {
+		t.Fatalf("expected expired entries to be refetched, got %d requests", calls.Load())
+	}
+}
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewLRUCache(2)
+	cache.Set("a", CacheEntry{Body: []byte("a")})
+	cache.Set("b", CacheEntry{Body: []byte("b")})
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	cache.Set("c", CacheEntry{Body: []byte("c")})
+	if _, ok := cache.Get("b"); ok {
+		t.Fatal("expected b, the least recently used entry, to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if entry, ok := cache.Get(key); !ok || string(entry.Body) != key {
+			t.Fatalf("expected %s to stay cached, got %q, %v", key, entry.Body, ok)
+		}
+	}
+	cache.Set("a", CacheEntry{Body: []byte("a2")})
+	if entry, _ := cache.Get("a"); string(entry.Body) != "a2" {
+		t.Fatalf("expected Set to replace the entry, got %q", entry.Body)
+	}
+}
+
+func TestDetailsCacheRevalidatesETag(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"id": "abc", "displayName": {"text": "Cafe"}}`))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache()
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, CacheTTL: time.Nanosecond, Cache: cache})
+	for range 2 {
+		details, err := client.Details(context.Background(), "abc")
+		if err != nil {
+			t.Fatalf("details error: %v", err)
+		}
+		if details.Name != "Cafe" {
+			t.Fatalf("unexpected details: %#v", details)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected a revalidation request, got %d", calls.Load())
+	}
+
+	// Without a cached body a 304 is an error, not an empty success.
+	uncached := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, Headers: http.Header{"If-None-Match": {`"v1"`}}})
+	if _, err := uncached.Details(context.Background(), "abc"); err == nil {
+		t.Fatal("expected an error for an unsolicited 304")
+	}
+}
+
+func TestSearchCacheIsOptIn(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"places": [{"id": "abc"}]}`))
+	}))
+	defer server.Close()
+
+	search := func(client *Client) {
+		t.Helper()
+		if _, err := client.Search(context.Background(), SearchRequest{Query: "coffee"}); err != nil {
+			t.Fatalf("search error: %v", err)
+		}
+	}
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, CacheTTL: time.Hour})
+	search(client)
+	search(client)
+	if calls.Load() != 2 {
+		t.Fatalf("expected searches to bypass the cache by default, got %d requests", calls.Load())
+	}
+
+	client = NewClient(Options{APIKey: "test-key", BaseURL: server.URL, CacheTTL: time.Hour, CacheSearches: true})
+	search(client)
+	search(client)
+	if calls.Load() != 3 {
+		t.Fatalf("expected the repeated search to be cached, got %d requests", calls.Load())
+	}
+}
@@ -0,0 +1,109 @@
+package goplaces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// limitRangePattern pulls the upper bound out of Google's range wording,
+// e.g. "maxResultCount must be between 1 and 20, inclusive".
+var limitRangePattern = regexp.MustCompile(`between \d+ and (\d+)`)
+
+// googleErrorBody is the JSON error envelope returned by Google APIs.
+type googleErrorBody struct {
+	Error struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Details []struct {
+			FieldViolations []struct {
+				Field       string `json:"field"`
+				Description string `json:"description"`
+			} `json:"fieldViolations"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// doClampedRequest is doRequest for bodies carrying a result count in field
+// (maxResultCount, pageSize). If the API rejects that value as out of range,
+// it retries once with the bound the API reports, or fallback when the error
+// names none. This keeps requests working if Google lowers a cap below the
+// limits validated here.
+func (c *Client) doClampedRequest(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	body map[string]any,
+	fieldMask string,
+	field string,
+	fallback int,
+) ([]byte, error) {
+	payload, err := c.doRequest(ctx, method, endpoint, body, fieldMask)
+	if err == nil {
+		return payload, nil
+	}
+	current, _ := body[field].(int)
+	limit, ok := rejectedLimit(err, field, current, fallback)
+	if !ok {
+		return nil, err
+	}
+	clamped := maps.Clone(body)
+	clamped[field] = limit
+	return c.doRequest(ctx, method, endpoint, clamped, fieldMask)
+}
+
+// rejectedLimit reports the value to retry with when err is an
+// INVALID_ARGUMENT about field. ok is false for unrelated errors and when
+// the limit would not be lower than current.
+func rejectedLimit(err error, field string, current, fallback int) (int, bool) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		return 0, false
+	}
+	var body googleErrorBody
+	if json.Unmarshal([]byte(apiErr.Body), &body) != nil || body.Error.Status != "INVALID_ARGUMENT" {
+		return 0, false
+	}
+
+	texts := []string{body.Error.Message}
+	mentioned := mentionsField(body.Error.Message, field)
+	for _, detail := range body.Error.Details {
+		for _, violation := range detail.FieldViolations {
+			if mentionsField(violation.Field, field) {
+				mentioned = true
+				texts = append(texts, violation.Description)
+			}
+		}
+	}
+	if !mentioned {
+		return 0, false
+	}
+
+	limit := fallback
+	for _, text := range texts {
+		if match := limitRangePattern.FindStringSubmatch(text); match != nil {
+			if bound, err := strconv.Atoi(match[1]); err == nil && bound > 0 {
+				limit = bound
+				break
+			}
+		}
+	}
+	if limit <= 0 || limit >= current {
+		return 0, false
+	}
+	return limit, true
+}
+
+// mentionsField matches camelCase body keys against the snake_case or
+// camelCase spelling Google uses in error text.
+func mentionsField(text, field string) bool {
+	normalize := func(value string) string {
+		return strings.ToLower(strings.ReplaceAll(value, "_", ""))
+	}
+	return strings.Contains(normalize(text), normalize(field))
+}
@@ -0,0 +1,89 @@
+package goplaces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNearbyClampsRejectedMaxResultCount(t *testing.T) {
+	var counts []float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		count := body["maxResultCount"].(float64)
+		counts = append(counts, count)
+		if count > 15 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"code": 400, "status": "INVALID_ARGUMENT",
+  "message": "Invalid argument.",
+  "details": [{"@type": "type.googleapis.com/google.rpc.BadRequest",
+    "fieldViolations": [{"field": "max_result_count", "description": "Must be between 1 and 15, inclusive."}]}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"places": [{"id": "abc"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	response, err := client.NearbySearch(context.Background(), NearbySearchRequest{
+		LocationRestriction: &LocationBias{Lat: 1, Lng: 2, RadiusM: 100},
+		Limit:               20,
+	})
+	if err != nil {
+		t.Fatalf("nearby error: %v", err)
+	}
+	if len(response.Results) != 1 {
+		t.Fatalf("unexpected results: %#v", response.Results)
+	}
+	if len(counts) != 2 || counts[0] != 20 || counts[1] != 15 {
+		t.Fatalf("expected one clamped retry, got counts %v", counts)
+	}
+}
+
+func TestClampRetriesOnlyOnce(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": {"code": 400, "status": "INVALID_ARGUMENT", "message": "pageSize must be between 1 and 5, inclusive."}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	_, err := client.Search(context.Background(), SearchRequest{Query: "coffee", Limit: 10})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || calls != 2 {
+		t.Fatalf("expected API error after one retry, calls=%d err=%v", calls, err)
+	}
+}
+
+func TestRejectedLimit(t *testing.T) {
+	invalid := func(body string) error { return &APIError{StatusCode: http.StatusBadRequest, Body: body} }
+	cases := []struct {
+		name    string
+		err     error
+		current int
+		want    int
+		ok      bool
+	}{
+		{"message bound", invalid(`{"error": {"status": "INVALID_ARGUMENT", "message": "maxResultCount must be between 1 and 10, inclusive."}}`), 20, 10, true},
+		{"fallback", invalid(`{"error": {"status": "INVALID_ARGUMENT", "message": "Invalid maxResultCount."}}`), 25, 20, true},
+		{"not lower", invalid(`{"error": {"status": "INVALID_ARGUMENT", "message": "maxResultCount must be between 1 and 20."}}`), 20, 0, false},
+		{"other field", invalid(`{"error": {"status": "INVALID_ARGUMENT", "message": "Invalid includedTypes."}}`), 20, 0, false},
+		{"other status", invalid(`{"error": {"status": "PERMISSION_DENIED", "message": "maxResultCount"}}`), 20, 0, false},
+		{"not json", invalid("bad request"), 20, 0, false},
+		{"server error", &APIError{StatusCode: http.StatusInternalServerError}, 20, 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := rejectedLimit(tc.err, "maxResultCount", tc.current, 20)
+		if got != tc.want || ok != tc.ok {
+			t.Fatalf("%s: got (%d, %v), want (%d, %v)", tc.name, got, ok, tc.want, tc.ok)
+		}
+	}
+}
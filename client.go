@@ -8,21 +8,48 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/time/rate"
 )
 
 // DefaultBaseURL is the default endpoint for the Places API (New).
 const DefaultBaseURL = "https://places.googleapis.com/v1"
 
+const apiKeyHeader = "X-Goog-Api-Key"
+
 // Client wraps access to the Google Places API.
 type Client struct {
 	apiKey        string
 	baseURL       string
 	routesBaseURL string
 	httpClient    *http.Client
+	headers       http.Header
+	requestHook   func(RequestInfo)
+	maxRetries    int
+	retryBackoff  time.Duration
+	retryMaxDelay time.Duration
+	// retryBudget holds the remaining MaxTotalRetries; nil when uncapped.
+	retryBudget *atomic.Int64
+	// limiter paces API requests (Options.RateLimit); nil when unlimited.
+	limiter *rate.Limiter
+	// normalizeQueries folds query text in cache keys (never on the wire).
+	normalizeQueries bool
+	// cache holds responses for cacheTTL; nil when caching is off.
+	cache         Cache
+	cacheTTL      time.Duration
+	cacheSearches bool
+	warn          func(string)
+	logger        *slog.Logger
+	// autoSessionToken fills empty autocomplete session tokens.
+	autoSessionToken bool
+	defaultLanguage  string
+	defaultRegion    string
 }
 
 // Options configures the Places client.
@@ -32,6 +59,76 @@ type Options struct {
 	RoutesBaseURL string
 	HTTPClient    *http.Client
 	Timeout       time.Duration
+	// Transport is used for the default HTTP client when HTTPClient is nil.
+	// Defaults to DefaultTransport().
+	Transport http.RoundTripper
+	// DefaultLanguage and DefaultRegion are used by every request that
+	// leaves Language or Region empty; per-request values win.
+	DefaultLanguage string
+	DefaultRegion   string
+	// NormalizeQueries trims, collapses whitespace, and lowercases queries
+	// when deriving response cache keys. The query sent to Google is unchanged.
+	NormalizeQueries bool
+	// CacheTTL keeps successful details responses for this long; 0 disables
+	// caching. Expired entries with an ETag are revalidated with
+	// If-None-Match, and a 304 renews them.
+	CacheTTL time.Duration
+	// Cache stores responses when CacheTTL is set. Defaults to
+	// NewMemoryCache(), an LRU of 1000 entries; implement Cache to share
+	// responses across processes (e.g. Redis).
+	Cache Cache
+	// CacheSearches also caches text search POSTs, keyed by the request body
+	// (see NormalizeQueries). Off by default: results depend on time of day.
+	CacheSearches bool
+	// Headers are applied after the default Content-Type/field mask headers,
+	// so they can override those or add new ones. X-Goog-Api-Key is always
+	// taken from APIKey and cannot be overridden here.
+	Headers http.Header
+	// MaxRetries retries 429/500/502/503/504 responses this many times
+	// (default 0: no retries, at most MaxRetriesLimit). 400/401/403 and
+	// other errors never retry.
+	MaxRetries int
+	// RetryBackoff is the base delay, doubled per attempt with jitter.
+	// Retry-After wins when present. Defaults to 250ms.
+	RetryBackoff time.Duration
+	// RetryMaxDelay caps each retry wait, backoff or Retry-After alike, so a
+	// server asking for minutes cannot stall a request. Defaults to 30s.
+	RetryMaxDelay time.Duration
+	// MaxTotalRetries caps retries across every request made by this client
+	// (e.g. all waypoints of a Route), so a degraded API cannot multiply
+	// MaxRetries into a runaway. Once spent, failures return immediately.
+	// 0 means no shared cap.
+	MaxTotalRetries int
+	// RateLimit caps API requests per second across this client, retries
+	// included. Each request waits for its turn (honoring ctx). 0 means
+	// unlimited.
+	RateLimit float64
+	// RateBurst lets this many requests go out back to back before
+	// RateLimit applies. Defaults to 1.
+	RateBurst int
+	// AutoSessionToken makes Autocomplete generate a fresh session token
+	// (NewAutocompleteSession) for each request that has none. The token
+	// used is returned in AutocompleteResponse.SessionToken.
+	AutoSessionToken bool
+	// Warn, when set, receives notices about requests the client adjusted,
+	// e.g. "dropped field reviews (not permitted)".
+	Warn func(message string)
+	// Logger, when set, gets a debug-level record of every HTTP round trip
+	// (method, URL, field mask, status, duration). The API key is redacted.
+	Logger *slog.Logger
+	// RequestHook, when set, is called after every HTTP round trip (including
+	// failed ones). It may be called concurrently, e.g. from NearbyGrid.
+	RequestHook func(RequestInfo)
+}
+
+// RequestInfo describes a completed HTTP request for Options.RequestHook.
+type RequestInfo struct {
+	Method string
+	URL    string
+	// StatusCode is 0 when no response was received.
+	StatusCode int
+	Duration   time.Duration
+	Err        error
 }
 
 // NewClient builds a client with sane defaults.
@@ -51,14 +148,63 @@ func NewClient(opts Options) *Client {
 		if timeout == 0 {
 			timeout = 10 * time.Second
 		}
-		client = &http.Client{Timeout: timeout}
+		transport := opts.Transport
+		if transport == nil {
+			transport = DefaultTransport()
+		}
+		client = &http.Client{Timeout: timeout, Transport: transport}
+	}
+
+	retryBackoff := opts.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = defaultRetryBackoff
+	}
+	retryMaxDelay := opts.RetryMaxDelay
+	if retryMaxDelay <= 0 {
+		retryMaxDelay = defaultRetryMaxDelay
+	}
+
+	var retryBudget *atomic.Int64
+	if opts.MaxTotalRetries > 0 {
+		retryBudget = new(atomic.Int64)
+		retryBudget.Store(int64(opts.MaxTotalRetries))
+	}
+
+	var limiter *rate.Limiter
+	if opts.RateLimit > 0 {
+		burst := max(opts.RateBurst, 1)
+		limiter = rate.NewLimiter(rate.Limit(opts.RateLimit), burst)
+	}
+
+	var cache Cache
+	if opts.CacheTTL > 0 {
+		cache = opts.Cache
+		if cache == nil {
+			cache = NewMemoryCache()
+		}
 	}
 
 	return &Client{
-		apiKey:        opts.APIKey,
-		baseURL:       baseURL,
-		routesBaseURL: routesBaseURL,
-		httpClient:    client,
+		apiKey:           opts.APIKey,
+		baseURL:          baseURL,
+		routesBaseURL:    routesBaseURL,
+		httpClient:       client,
+		headers:          opts.Headers.Clone(),
+		requestHook:      opts.RequestHook,
+		maxRetries:       min(max(opts.MaxRetries, 0), MaxRetriesLimit),
+		retryBackoff:     retryBackoff,
+		retryMaxDelay:    retryMaxDelay,
+		retryBudget:      retryBudget,
+		limiter:          limiter,
+		normalizeQueries: opts.NormalizeQueries,
+		cache:            cache,
+		cacheTTL:         opts.CacheTTL,
+		cacheSearches:    opts.CacheSearches,
+		warn:             opts.Warn,
+		logger:           opts.Logger,
+		autoSessionToken: opts.AutoSessionToken,
+		defaultLanguage:  strings.TrimSpace(opts.DefaultLanguage),
+		defaultRegion:    strings.TrimSpace(opts.DefaultRegion),
 	}
 }
 
@@ -69,34 +215,126 @@ func (c *Client) doRequest(
 	body any,
 	fieldMask string,
 ) ([]byte, error) {
+	payload, _, err := c.doConditionalRequest(ctx, method, endpoint, body, fieldMask, "")
+	return payload, err
+}
+
+// doConditionalRequest is doRequest that sends If-None-Match when etag is
+// set and also returns the response ETag. A 304 is errNotModified.
+func (c *Client) doConditionalRequest(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	body any,
+	fieldMask string,
+	etag string,
+) ([]byte, string, error) {
 	if strings.TrimSpace(c.apiKey) == "" {
-		return nil, ErrMissingAPIKey
+		return nil, "", ErrMissingAPIKey
 	}
 
-	var reader io.Reader
+	var encoded []byte
 	if body != nil {
 		payload, err := json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("goplaces: encode request: %w", err)
+			return nil, "", fmt.Errorf("goplaces: encode request: %w", err)
 		}
-		reader = bytes.NewReader(payload)
+		encoded = payload
+	}
+
+	result, err := c.doRetried(ctx, method, endpoint, encoded, fieldMask, etag)
+	// A key without entitlement for one optional field fails the whole
+	// request; retry once without that field.
+	if field, ok := deniedField(err, fieldMask); ok {
+		c.warnf("dropped field %s (not permitted)", field)
+		result, err = c.doRetried(ctx, method, endpoint, encoded, withoutField(fieldMask, field), etag)
+	}
+	return result.payload, result.etag, err
+}
+
+// doRetried runs doAttempt under the rate limit, retrying per MaxRetries.
+func (c *Client) doRetried(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	body []byte,
+	fieldMask string,
+	etag string,
+) (attemptResult, error) {
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return attemptResult{}, fmt.Errorf("goplaces: rate limit: %w", err)
+			}
+		}
+		result, retryAfter, err := c.doAttempt(ctx, method, endpoint, body, fieldMask, etag)
+		if err == nil || attempt >= c.maxRetries || !retryable(err) || !c.takeRetry() {
+			return result, err
+		}
+		if waitErr := waitRetry(ctx, retryDelay(c.retryBackoff, c.retryMaxDelay, attempt, retryAfter)); waitErr != nil {
+			// Keep both: callers match the context error with errors.Is and
+			// the last API error with errors.As.
+			return attemptResult{}, fmt.Errorf("goplaces: retry abandoned: %w (last error: %w)", waitErr, err)
+		}
+	}
+}
+
+// attemptResult is a successful round trip's body and ETag.
+type attemptResult struct {
+	payload []byte
+	etag    string
+}
+
+// doAttempt performs one round trip. retryAfter is parsed from the response
+// when the server sent one.
+func (c *Client) doAttempt(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	body []byte,
+	fieldMask string,
+	etag string,
+) (attemptResult, time.Duration, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
 	}
 
 	request, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
 	if err != nil {
-		return nil, fmt.Errorf("goplaces: build request: %w", err)
+		return attemptResult{}, 0, fmt.Errorf("goplaces: build request: %w", err)
 	}
 
 	request.Header.Set("Content-Type", "application/json")
-	request.Header.Set("X-Goog-Api-Key", c.apiKey)
+	request.Header.Set(apiKeyHeader, c.apiKey)
 	// Field masks trim API payloads and keep responses fast/cheap.
 	if strings.TrimSpace(fieldMask) != "" {
 		request.Header.Set("X-Goog-FieldMask", fieldMask)
 	}
+	if etag != "" {
+		request.Header.Set("If-None-Match", etag)
+	}
+	// Caller headers win over the defaults above, except for the API key.
+	for key, values := range c.headers {
+		if http.CanonicalHeaderKey(key) == apiKeyHeader {
+			continue
+		}
+		request.Header[http.CanonicalHeaderKey(key)] = values
+	}
 
+	started := time.Now()
 	response, err := c.httpClient.Do(request)
+	duration := time.Since(started)
+	status := 0
+	if response != nil {
+		status = response.StatusCode
+	}
+	c.logRequest(ctx, request, fieldMask, status, duration, err)
+	if c.requestHook != nil {
+		c.requestHook(RequestInfo{Method: method, URL: endpoint, StatusCode: status, Duration: duration, Err: err})
+	}
 	if err != nil {
-		return nil, fmt.Errorf("goplaces: request failed: %w", err)
+		return attemptResult{}, 0, fmt.Errorf("goplaces: request failed: %w", err)
 	}
 	defer func() {
 		_ = response.Body.Close()
@@ -105,19 +343,54 @@ func (c *Client) doRequest(
 	// Hard-cap payload size to avoid runaway error bodies.
 	payload, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
 	if err != nil {
-		return nil, fmt.Errorf("goplaces: read response: %w", err)
+		return attemptResult{}, 0, fmt.Errorf("goplaces: read response: %w", err)
 	}
 
 	if response.StatusCode >= http.StatusBadRequest {
-		apiErr := &APIError{StatusCode: response.StatusCode, Body: strings.TrimSpace(string(payload))}
-		return nil, apiErr
+		apiErr := &APIError{
+			StatusCode: response.StatusCode,
+			Body:       strings.TrimSpace(string(payload)),
+			Method:     method,
+			Endpoint:   c.operation(request.URL),
+		}
+		return attemptResult{}, parseRetryAfter(response.Header.Get("Retry-After"), time.Now()), apiErr
+	}
+	if response.StatusCode == http.StatusNotModified {
+		return attemptResult{}, 0, errNotModified
 	}
 
 	if len(payload) == 0 {
-		return nil, errors.New("goplaces: empty response")
+		return attemptResult{}, 0, errors.New("goplaces: empty response")
 	}
 
-	return payload, nil
+	return attemptResult{payload: payload, etag: response.Header.Get("ETag")}, 0, nil
+}
+
+// locale fills an empty language or region from Options.DefaultLanguage
+// and Options.DefaultRegion, then rejects malformed codes.
+func (c *Client) locale(language, region string) (string, string, error) {
+	if strings.TrimSpace(language) == "" {
+		language = c.defaultLanguage
+	}
+	if strings.TrimSpace(region) == "" {
+		region = c.defaultRegion
+	}
+	if err := validateLocale(language, region); err != nil {
+		return "", "", err
+	}
+	return language, region, nil
+}
+
+// operation is u's path below the Places or Routes base URL, for errors:
+// "places:searchText", "places/<id>", "directions/v2:computeRoutes".
+func (c *Client) operation(u *url.URL) string {
+	path := u.Scheme + "://" + u.Host + u.Path
+	for _, base := range []string{c.baseURL, c.routesBaseURL} {
+		if trimmed, ok := strings.CutPrefix(path, base); ok {
+			return strings.TrimPrefix(trimmed, "/")
+		}
+	}
+	return strings.TrimPrefix(u.Path, "/")
 }
 
 func (c *Client) buildURL(path string, query map[string]string) (string, error) {
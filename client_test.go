@@ -5,9 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -44,6 +47,7 @@ func TestSearchSuccess(t *testing.T) {
       "formattedAddress": "123 Street",
       "location": {"latitude": 1.23, "longitude": 4.56},
       "rating": 4.7,
+      "userRatingCount": 1203,
       "priceLevel": "PRICE_LEVEL_MODERATE",
       "types": ["cafe"],
       "currentOpeningHours": {"openNow": true}
@@ -95,6 +99,9 @@ func TestSearchSuccess(t *testing.T) {
 	if result.PriceLevel == nil || *result.PriceLevel != 2 {
 		t.Fatalf("unexpected price level: %#v", result.PriceLevel)
 	}
+	if result.UserRatingCount == nil || *result.UserRatingCount != 1203 {
+		t.Fatalf("unexpected user rating count: %#v", result.UserRatingCount)
+	}
 	if result.OpenNow == nil || *result.OpenNow != true {
 		t.Fatalf("unexpected openNow: %#v", result.OpenNow)
 	}
@@ -119,6 +126,117 @@ func TestSearchSuccess(t *testing.T) {
 	}
 }
 
+func TestSearchPageSizeAccumulatesToLimit(t *testing.T) {
+	var pageSizes []float64
+	var tokens []any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		pageSizes = append(pageSizes, body["pageSize"].(float64))
+		tokens = append(tokens, body["pageToken"])
+		page := len(pageSizes)
+		_, _ = fmt.Fprintf(w, `{"places": [{"id": "p%da"}, {"id": "p%db"}], "nextPageToken": "t%d"}`, page, page, page)
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	response, err := client.Search(context.Background(), SearchRequest{Query: "coffee", Limit: 5, PageSize: 2})
+	if err != nil {
+		t.Fatalf("search error: %v", err)
+	}
+	if len(pageSizes) != 3 {
+		t.Fatalf("expected 3 calls, got %d", len(pageSizes))
+	}
+	for _, size := range pageSizes {
+		if size != 2 {
+			t.Fatalf("unexpected pageSize: %v", pageSizes)
+		}
+	}
+	if tokens[0] != nil || tokens[1] != "t1" || tokens[2] != "t2" {
+		t.Fatalf("unexpected page tokens: %#v", tokens)
+	}
+	if len(response.Results) != 5 || response.Results[4].PlaceID != "p3a" {
+		t.Fatalf("unexpected results: %#v", response.Results)
+	}
+}
+
+func TestSearchPageSizeValidation(t *testing.T) {
+	client := NewClient(Options{APIKey: "test-key", BaseURL: "http://example.com"})
+	_, err := client.Search(context.Background(), SearchRequest{Query: "coffee", PageSize: 21})
+	var validation ValidationError
+	if !errors.As(err, &validation) || validation.Field != "page_size" {
+		t.Fatalf("expected page_size validation error, got %v", err)
+	}
+}
+
+func TestSearchRankPreference(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	_, err := client.Search(context.Background(), SearchRequest{
+		Query:          "coffee",
+		RankPreference: RankPreferenceDistance,
+		LocationBias:   &LocationBias{Lat: 1, Lng: 2, RadiusM: 500},
+	})
+	if err != nil {
+		t.Fatalf("search error: %v", err)
+	}
+	if gotBody["rankPreference"] != "DISTANCE" {
+		t.Fatalf("expected rankPreference DISTANCE, got %#v", gotBody["rankPreference"])
+	}
+
+	var validation ValidationError
+	_, err = client.Search(context.Background(), SearchRequest{Query: "coffee", RankPreference: "DISTANCE"})
+	if !errors.As(err, &validation) || validation.Field != "rank_preference" {
+		t.Fatalf("expected rank_preference validation error without location, got %v", err)
+	}
+	_, err = client.Search(context.Background(), SearchRequest{Query: "coffee", RankPreference: "distance"})
+	if !errors.As(err, &validation) || validation.Field != "rank_preference" {
+		t.Fatalf("expected lowercase distance to be normalized and still need a location, got %v", err)
+	}
+	_, err = client.Search(context.Background(), SearchRequest{Query: "coffee", RankPreference: "POPULARITY"})
+	if !errors.As(err, &validation) || validation.Field != "rank_preference" {
+		t.Fatalf("expected rank_preference validation error for unknown value, got %v", err)
+	}
+}
+
+func TestSearchStrictTypeFiltering(t *testing.T) {
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		bodies = append(bodies, body)
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	filters := &Filters{Types: []string{"cafe"}}
+	if _, err := client.Search(context.Background(), SearchRequest{Query: "coffee", Filters: filters}); err != nil {
+		t.Fatalf("search error: %v", err)
+	}
+	if _, err := client.Search(context.Background(), SearchRequest{Query: "coffee", Filters: filters, StrictTypeFiltering: true}); err != nil {
+		t.Fatalf("search error: %v", err)
+	}
+	if _, ok := bodies[0]["strictTypeFiltering"]; ok {
+		t.Fatalf("unexpected strictTypeFiltering by default: %#v", bodies[0])
+	}
+	if bodies[1]["strictTypeFiltering"] != true {
+		t.Fatalf("expected strictTypeFiltering true, got %#v", bodies[1]["strictTypeFiltering"])
+	}
+}
+
 func TestSearchHTTPError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
@@ -135,6 +253,17 @@ func TestSearchHTTPError(t *testing.T) {
 	if apiErr.StatusCode != http.StatusBadRequest {
 		t.Fatalf("unexpected status: %d", apiErr.StatusCode)
 	}
+	if apiErr.Method != http.MethodPost || apiErr.Endpoint != "places:searchText" {
+		t.Fatalf("unexpected request context: %s %s", apiErr.Method, apiErr.Endpoint)
+	}
+	if err.Error() != "goplaces: places:searchText 400: bad" {
+		t.Fatalf("unexpected message: %s", err.Error())
+	}
+
+	_, err = client.DetailsWithOptions(context.Background(), DetailsRequest{PlaceID: "abc", Language: "en"})
+	if !errors.As(err, &apiErr) || apiErr.Method != http.MethodGet || !strings.Contains(err.Error(), "places/abc 400") {
+		t.Fatalf("expected details endpoint in error, got %v", err)
+	}
 }
 
 func TestSearchInvalidJSON(t *testing.T) {
@@ -198,12 +327,14 @@ func TestAutocompleteSuccess(t *testing.T) {
 
 	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL + "/v1"})
 	response, err := client.Autocomplete(context.Background(), AutocompleteRequest{
-		Input:        "cof",
-		Limit:        5,
-		SessionToken: "session",
-		Language:     "en",
-		Region:       "US",
-		LocationBias: &LocationBias{Lat: 1.1, Lng: 2.2, RadiusM: 100},
+		Input:                "cof",
+		Limit:                5,
+		SessionToken:         "session",
+		Language:             "en",
+		Region:               "US",
+		LocationBias:         &LocationBias{Lat: 1.1, Lng: 2.2, RadiusM: 100},
+		IncludedPrimaryTypes: []string{"cafe", "bakery"},
+		Origin:               &LatLng{Lat: 47.6, Lng: -122.3},
 	})
 	if err != nil {
 		t.Fatalf("autocomplete error: %v", err)
@@ -234,6 +365,208 @@ func TestAutocompleteSuccess(t *testing.T) {
 	if locationBias["circle"] == nil {
 		t.Fatalf("missing location bias circle")
 	}
+	origin, _ := gotRequest["origin"].(map[string]any)
+	if origin["latitude"] != 47.6 || origin["longitude"] != -122.3 {
+		t.Fatalf("unexpected origin: %#v", gotRequest["origin"])
+	}
+	if types, ok := gotRequest["includedPrimaryTypes"].([]any); !ok || len(types) != 2 || types[1] != "bakery" {
+		t.Fatalf("unexpected includedPrimaryTypes: %#v", gotRequest["includedPrimaryTypes"])
+	}
+
+	gotRequest = nil
+	if _, err := client.Autocomplete(context.Background(), AutocompleteRequest{Input: "cof"}); err != nil {
+		t.Fatalf("autocomplete error: %v", err)
+	}
+	if _, ok := gotRequest["includedPrimaryTypes"]; ok {
+		t.Fatalf("expected no includedPrimaryTypes, got %#v", gotRequest["includedPrimaryTypes"])
+	}
+	if _, ok := gotRequest["inputOffset"]; ok {
+		t.Fatalf("expected no inputOffset, got %#v", gotRequest["inputOffset"])
+	}
+	if _, ok := gotRequest["origin"]; ok {
+		t.Fatalf("expected no origin, got %#v", gotRequest["origin"])
+	}
+
+	offset := 0
+	if _, err := client.Autocomplete(context.Background(), AutocompleteRequest{Input: "cof", InputOffset: &offset}); err != nil {
+		t.Fatalf("autocomplete error: %v", err)
+	}
+	if gotRequest["inputOffset"] != float64(0) {
+		t.Fatalf("unexpected inputOffset: %#v", gotRequest["inputOffset"])
+	}
+	offset = 4
+	_, err = client.Autocomplete(context.Background(), AutocompleteRequest{Input: "café", InputOffset: &offset})
+	if err != nil {
+		t.Fatalf("offset at end of multi-byte input should be valid: %v", err)
+	}
+	offset = 5
+	_, err = client.Autocomplete(context.Background(), AutocompleteRequest{Input: "café", InputOffset: &offset})
+	var offsetErr ValidationError
+	if !errors.As(err, &offsetErr) || offsetErr.Field != "input_offset" {
+		t.Fatalf("expected input_offset validation error, got %v", err)
+	}
+
+	_, err = client.Autocomplete(context.Background(), AutocompleteRequest{
+		Input:                "cof",
+		IncludedPrimaryTypes: []string{"a", "b", "c", "d", "e", "f"},
+	})
+	var validation ValidationError
+	if !errors.As(err, &validation) || validation.Field != "included_primary_types" {
+		t.Fatalf("expected included_primary_types validation error, got %v", err)
+	}
+}
+
+func TestValidateLocale(t *testing.T) {
+	for _, ok := range [][2]string{{"", ""}, {"en", "US"}, {"en-US", "us"}, {"zh-Hant-TW", "TW"}, {"es-419", ""}, {"fil", "PH"}} {
+		if err := validateLocale(ok[0], ok[1]); err != nil {
+			t.Fatalf("validateLocale(%q, %q): %v", ok[0], ok[1], err)
+		}
+	}
+	tests := []struct {
+		language, region, field, message string
+	}{
+		{"english", "", "language", "BCP-47"},
+		{"en_US", "", "language", `use "en-US"`},
+		{"e", "", "language", "BCP-47"},
+		{"", "USA", "region", "two-letter"},
+		{"", "1", "region", "two-letter"},
+	}
+	for _, tt := range tests {
+		var validation ValidationError
+		err := validateLocale(tt.language, tt.region)
+		if !errors.As(err, &validation) || validation.Field != tt.field || !strings.Contains(validation.Message, tt.message) {
+			t.Fatalf("validateLocale(%q, %q): expected %s error mentioning %q, got %v", tt.language, tt.region, tt.field, tt.message, err)
+		}
+	}
+}
+
+func TestLocaleValidatedBeforeRequest(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	_, err := client.Search(context.Background(), SearchRequest{Query: "cafe", Region: "USA"})
+	var validation ValidationError
+	if !errors.As(err, &validation) || validation.Field != "region" {
+		t.Fatalf("expected region validation error, got %v", err)
+	}
+	bad := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, DefaultLanguage: "english"})
+	if _, err := bad.Details(context.Background(), "abc"); !errors.As(err, &validation) || validation.Field != "language" {
+		t.Fatalf("expected language validation error for the default, got %v", err)
+	}
+	if requests != 0 {
+		t.Fatalf("expected no requests, got %d", requests)
+	}
+}
+
+func TestDefaultLocale(t *testing.T) {
+	type locale struct{ language, region any }
+	var got []locale
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			got = append(got, locale{r.URL.Query().Get("languageCode"), r.URL.Query().Get("regionCode")})
+			_, _ = w.Write([]byte(`{"id": "abc"}`))
+			return
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		got = append(got, locale{body["languageCode"], body["regionCode"]})
+		_, _ = w.Write([]byte(`{"places": [{"id": "abc", "location": {"latitude": 1, "longitude": 2}}], "suggestions": []}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, DefaultLanguage: "de", DefaultRegion: "AT"})
+	circle := &LocationBias{Lat: 1, Lng: 2, RadiusM: 10}
+	calls := []func() error{
+		func() error { _, err := client.Search(ctx, SearchRequest{Query: "cafe"}); return err },
+		func() error {
+			_, err := client.NearbySearch(ctx, NearbySearchRequest{LocationRestriction: circle})
+			return err
+		},
+		func() error { _, err := client.Details(ctx, "abc"); return err },
+		func() error { _, err := client.Resolve(ctx, LocationResolveRequest{LocationText: "Wien"}); return err },
+		func() error { _, err := client.Reverse(ctx, 1, 2); return err },
+		func() error { _, err := client.Autocomplete(ctx, AutocompleteRequest{Input: "ca"}); return err },
+		// Per-request values win, independently for language and region.
+		func() error { _, err := client.Search(ctx, SearchRequest{Query: "cafe", Language: "en"}); return err },
+		func() error {
+			_, err := client.DetailsWithOptions(ctx, DetailsRequest{PlaceID: "abc", Region: "DE"})
+			return err
+		},
+	}
+	for i, call := range calls {
+		if err := call(); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+
+	want := []locale{
+		{"de", "AT"}, {"de", "AT"}, {"de", "AT"}, {"de", "AT"}, {"de", "AT"}, {"de", "AT"},
+		{"en", "AT"}, {"de", "DE"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d requests, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("request %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	plain := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	got = nil
+	if _, err := plain.Search(ctx, SearchRequest{Query: "cafe"}); err != nil {
+		t.Fatalf("search error: %v", err)
+	}
+	if got[0] != (locale{nil, nil}) {
+		t.Fatalf("expected no locale without defaults, got %v", got[0])
+	}
+}
+
+func TestAutocompleteAutoSessionToken(t *testing.T) {
+	var tokens []any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		tokens = append(tokens, body["sessionToken"])
+		_, _ = w.Write([]byte(`{"suggestions": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, AutoSessionToken: true})
+	first, err := client.Autocomplete(context.Background(), AutocompleteRequest{Input: "cof"})
+	if err != nil {
+		t.Fatalf("autocomplete error: %v", err)
+	}
+	second, err := client.Autocomplete(context.Background(), AutocompleteRequest{Input: "coff"})
+	if err != nil {
+		t.Fatalf("autocomplete error: %v", err)
+	}
+	given, err := client.Autocomplete(context.Background(), AutocompleteRequest{Input: "coffe", SessionToken: "mine"})
+	if err != nil {
+		t.Fatalf("autocomplete error: %v", err)
+	}
+
+	uuidV4 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if !uuidV4.MatchString(first.SessionToken) || first.SessionToken == second.SessionToken {
+		t.Fatalf("expected fresh UUID v4 tokens, got %q and %q", first.SessionToken, second.SessionToken)
+	}
+	if tokens[0] != first.SessionToken || tokens[1] != second.SessionToken || tokens[2] != "mine" || given.SessionToken != "mine" {
+		t.Fatalf("unexpected session tokens sent: %v", tokens)
+	}
+
+	plain := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	if response, err := plain.Autocomplete(context.Background(), AutocompleteRequest{Input: "cof"}); err != nil || response.SessionToken != "" {
+		t.Fatalf("expected no token without AutoSessionToken, got %q (%v)", response.SessionToken, err)
+	}
+	if tokens[3] != nil {
+		t.Fatalf("expected no sessionToken in body, got %v", tokens[3])
+	}
 }
 
 func TestAutocompleteLimitTrims(t *testing.T) {
@@ -299,12 +632,14 @@ func TestNearbySearchSuccess(t *testing.T) {
 
 	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL + "/v1"})
 	response, err := client.NearbySearch(context.Background(), NearbySearchRequest{
-		LocationRestriction: &LocationBias{Lat: 40.0, Lng: -70.0, RadiusM: 500},
-		Limit:               5,
-		IncludedTypes:       []string{"cafe"},
-		ExcludedTypes:       []string{"bar"},
-		Language:            "en",
-		Region:              "US",
+		LocationRestriction:  &LocationBias{Lat: 40.0, Lng: -70.0, RadiusM: 500},
+		Limit:                5,
+		IncludedTypes:        []string{"cafe"},
+		ExcludedTypes:        []string{"bar"},
+		IncludedPrimaryTypes: []string{"cafe", "bakery"},
+		ExcludedPrimaryTypes: []string{"gas_station"},
+		Language:             "en",
+		Region:               "US",
 	})
 	if err != nil {
 		t.Fatalf("nearby error: %v", err)
@@ -328,6 +663,127 @@ func TestNearbySearchSuccess(t *testing.T) {
 	if _, ok := gotRequest["locationRestriction"].(map[string]any); !ok {
 		t.Fatalf("unexpected locationRestriction: %#v", gotRequest["locationRestriction"])
 	}
+	if primary, _ := gotRequest["includedPrimaryTypes"].([]any); len(primary) != 2 || primary[1] != "bakery" {
+		t.Fatalf("unexpected includedPrimaryTypes: %#v", gotRequest["includedPrimaryTypes"])
+	}
+	if excluded, _ := gotRequest["excludedPrimaryTypes"].([]any); len(excluded) != 1 || excluded[0] != "gas_station" {
+		t.Fatalf("unexpected excludedPrimaryTypes: %#v", gotRequest["excludedPrimaryTypes"])
+	}
+}
+
+func TestValidateNearbyPlaceTypes(t *testing.T) {
+	base := NearbySearchRequest{LocationRestriction: &LocationBias{Lat: 1, Lng: 2, RadiusM: 500}, Limit: 5}
+	tests := []struct {
+		included, excluded []string
+		field, message     string
+	}{
+		{[]string{"cafe", "bakery"}, []string{"bar"}, "", ""},
+		{[]string{"cafe", "coffe_shop"}, nil, "included_types", `"coffe_shop"`},
+		{nil, []string{"fastfood"}, "excluded_types", `"fastfood"`},
+		{[]string{"cafe", "bar"}, []string{"bar"}, "excluded_types", `"bar" is also an included type`},
+	}
+	for _, tt := range tests {
+		request := base
+		request.IncludedTypes, request.ExcludedTypes = tt.included, tt.excluded
+		err := validateNearbyRequest(request)
+		if tt.field == "" {
+			if err != nil {
+				t.Fatalf("%v/%v: unexpected error: %v", tt.included, tt.excluded, err)
+			}
+			continue
+		}
+		var validation ValidationError
+		if !errors.As(err, &validation) || validation.Field != tt.field || !strings.Contains(validation.Message, tt.message) {
+			t.Fatalf("%v/%v: expected %s error mentioning %s, got %v", tt.included, tt.excluded, tt.field, tt.message, err)
+		}
+	}
+}
+
+func TestPlaceTypesSorted(t *testing.T) {
+	types := PlaceTypes()
+	if !slices.IsSorted(types) || !slices.Contains(types, "cafe") || !slices.Contains(types, "electric_vehicle_charging_station") {
+		t.Fatalf("unexpected place types: %v", types)
+	}
+}
+
+func TestValidateNearbyPrimaryTypeOverlap(t *testing.T) {
+	request := NearbySearchRequest{
+		LocationRestriction:  &LocationBias{Lat: 1, Lng: 2, RadiusM: 500},
+		Limit:                5,
+		IncludedPrimaryTypes: []string{"cafe", "bakery"},
+		ExcludedPrimaryTypes: []string{"bakery"},
+	}
+	var validation ValidationError
+	if err := validateNearbyRequest(request); !errors.As(err, &validation) || validation.Field != "excluded_primary_types" {
+		t.Fatalf("expected excluded_primary_types validation error, got %v", err)
+	}
+	request.ExcludedPrimaryTypes = []string{"gas_station"}
+	if err := validateNearbyRequest(request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNearbySearchRankPreference(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	request := NearbySearchRequest{
+		LocationRestriction: &LocationBias{Lat: 1, Lng: 2, RadiusM: 500},
+		RankPreference:      "distance",
+	}
+	if _, err := client.NearbySearch(context.Background(), request); err != nil {
+		t.Fatalf("nearby error: %v", err)
+	}
+	if gotBody["rankPreference"] != RankPreferenceDistance {
+		t.Fatalf("expected rankPreference DISTANCE, got %#v", gotBody["rankPreference"])
+	}
+
+	request.RankPreference = RankPreferenceRelevance
+	_, err := client.NearbySearch(context.Background(), request)
+	var validation ValidationError
+	if !errors.As(err, &validation) || validation.Field != "rank_preference" {
+		t.Fatalf("expected rank_preference validation error, got %v", err)
+	}
+}
+
+func TestNearbySearchMinRatingFiltersClientSide(t *testing.T) {
+	var gotRequest map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotRequest); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{
+  "places": [
+    {"id": "good", "rating": 4.6},
+    {"id": "meh", "rating": 3.5},
+    {"id": "unrated"}
+  ]
+}`))
+	}))
+	defer server.Close()
+
+	minRating := 4.0
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	response, err := client.NearbySearch(context.Background(), NearbySearchRequest{
+		LocationRestriction: &LocationBias{Lat: 1, Lng: 2, RadiusM: 300},
+		MinRating:           &minRating,
+	})
+	if err != nil {
+		t.Fatalf("nearby error: %v", err)
+	}
+	if len(response.Results) != 1 || response.Results[0].PlaceID != "good" {
+		t.Fatalf("unexpected results: %#v", response.Results)
+	}
+	if _, ok := gotRequest["minRating"]; ok {
+		t.Fatalf("minRating must not be sent to nearby: %#v", gotRequest)
+	}
 }
 
 func TestPhotoMediaSuccess(t *testing.T) {
@@ -363,6 +819,57 @@ func TestPhotoMediaSuccess(t *testing.T) {
 	}
 }
 
+func TestPhotoBytesDownloadsWithoutAPIKey(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/places/place-1/photos/photo-1/media":
+			_, _ = w.Write([]byte(`{"name": "places/place-1/photos/photo-1", "photoUri": "` + server.URL + `/image"}`))
+		case "/image":
+			if r.Header.Get("X-Goog-Api-Key") != "" {
+				t.Fatalf("api key leaked to photo host")
+			}
+			w.Header().Set("Content-Type", "image/jpeg")
+			_, _ = w.Write([]byte("jpeg-bytes"))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL + "/v1"})
+	var buf bytes.Buffer
+	download, err := client.PhotoBytes(context.Background(), PhotoMediaRequest{Name: "places/place-1/photos/photo-1"}, &buf)
+	if err != nil {
+		t.Fatalf("photo bytes error: %v", err)
+	}
+	if buf.String() != "jpeg-bytes" {
+		t.Fatalf("unexpected body: %q", buf.String())
+	}
+	if download.ContentType != "image/jpeg" || download.Bytes != int64(len("jpeg-bytes")) {
+		t.Fatalf("unexpected download: %#v", download)
+	}
+}
+
+func TestPhotoBytesHostError(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/image" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"photoUri": "` + server.URL + `/image"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL + "/v1"})
+	_, err := client.PhotoBytes(context.Background(), PhotoMediaRequest{Name: "places/place-1/photos/photo-1"}, io.Discard)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 api error, got %v", err)
+	}
+}
+
 func TestDetailsSuccess(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/v1/places/place-123" {
@@ -379,7 +886,7 @@ func TestDetailsSuccess(t *testing.T) {
 		}
 		_, _ = w.Write([]byte(`{
   "id": "place-123",
-  "displayName": {"text": "Park"},
+  "displayName": {"text": "Park", "languageCode": "en-US"},
   "formattedAddress": "Central",
   "location": {"latitude": 10, "longitude": 20},
   "rating": 4.2,
@@ -387,7 +894,11 @@ func TestDetailsSuccess(t *testing.T) {
   "types": ["park"],
   "regularOpeningHours": {"weekdayDescriptions": ["Mon: 9-5"]},
   "currentOpeningHours": {"openNow": false},
-  "nationalPhoneNumber": "+1 555",
+  "businessStatus": "CLOSED_TEMPORARILY",
+  "utcOffsetMinutes": -210,
+  "plusCode": {"globalCode": "849VCWC8+R9", "compoundCode": "CWC8+R9 Seattle, WA, USA"},
+  "nationalPhoneNumber": "(555) 010-0199",
+  "internationalPhoneNumber": "+1 555-010-0199",
   "websiteUri": "https://example.com"
 }`))
 	}))
@@ -408,6 +919,21 @@ func TestDetailsSuccess(t *testing.T) {
 	if place.OpenNow == nil || *place.OpenNow != false {
 		t.Fatalf("unexpected openNow")
 	}
+	if place.BusinessStatus != BusinessStatusClosedTemporarily {
+		t.Fatalf("unexpected business status: %q", place.BusinessStatus)
+	}
+	if place.UTCOffsetMinutes == nil || *place.UTCOffsetMinutes != -210 {
+		t.Fatalf("unexpected utc offset: %v", place.UTCOffsetMinutes)
+	}
+	if place.Name != "Park" || place.NameLanguage != "en-US" {
+		t.Fatalf("unexpected name/language: %q %q", place.Name, place.NameLanguage)
+	}
+	if place.Phone != "(555) 010-0199" || place.InternationalPhone != "+1 555-010-0199" {
+		t.Fatalf("unexpected phones: %q / %q", place.Phone, place.InternationalPhone)
+	}
+	if place.PlusCode == nil || place.PlusCode.GlobalCode != "849VCWC8+R9" || place.PlusCode.CompoundCode != "CWC8+R9 Seattle, WA, USA" {
+		t.Fatalf("unexpected plus code: %#v", place.PlusCode)
+	}
 	if len(place.Hours) != 1 {
 		t.Fatalf("unexpected hours")
 	}
@@ -517,6 +1043,190 @@ func TestDetailsFieldMaskForRequest(t *testing.T) {
 	if !strings.Contains(got, "reviews") || !strings.Contains(got, "photos") {
 		t.Fatalf("expected reviews and photos in field mask: %s", got)
 	}
+	req = DetailsRequest{IncludeServices: true}
+	got = detailsFieldMaskForRequest(req)
+	if !strings.HasSuffix(got, ","+detailsFieldMaskServices) {
+		t.Fatalf("expected service options in field mask: %s", got)
+	}
+	req = DetailsRequest{IncludeAddressComponents: true}
+	got = detailsFieldMaskForRequest(req)
+	if !strings.HasSuffix(got, ",addressComponents") {
+		t.Fatalf("expected addressComponents in field mask: %s", got)
+	}
+	req = DetailsRequest{IncludeShortAddress: true}
+	got = detailsFieldMaskForRequest(req)
+	if !strings.HasSuffix(got, ",shortFormattedAddress") {
+		t.Fatalf("expected shortFormattedAddress in field mask: %s", got)
+	}
+}
+
+func TestDetailsViewport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.Header.Get("X-Goog-FieldMask"), ",viewport") {
+			t.Fatalf("expected viewport in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+		}
+		_, _ = w.Write([]byte(`{
+  "id": "place-123",
+  "viewport": {
+    "low": {"latitude": 47.59, "longitude": -122.35},
+    "high": {"latitude": 47.62, "longitude": -122.31}
+  }
+}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL + "/v1"})
+	details, err := client.DetailsWithOptions(context.Background(), DetailsRequest{
+		PlaceID:         "place-123",
+		IncludeViewport: true,
+	})
+	if err != nil {
+		t.Fatalf("details error: %v", err)
+	}
+	box := details.Viewport
+	if box == nil {
+		t.Fatalf("expected viewport")
+	}
+	if box.SW.Lat > box.NE.Lat {
+		t.Fatalf("expected low lat <= high lat, got %#v", box)
+	}
+	if box.SW.Lng != -122.35 || box.NE.Lng != -122.31 {
+		t.Fatalf("unexpected viewport: %#v", box)
+	}
+	if mapViewport(&viewportPayload{Low: &location{}}) != nil {
+		t.Fatalf("expected nil viewport without both corners")
+	}
+}
+
+func TestDetailsEVChargeOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.Header.Get("X-Goog-FieldMask"), ",evChargeOptions") {
+			t.Fatalf("expected evChargeOptions in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+		}
+		_, _ = w.Write([]byte(`{
+  "id": "place-123",
+  "evChargeOptions": {
+    "connectorCount": 6,
+    "connectorAggregation": [
+      {"type": "EV_CONNECTOR_TYPE_CCS_COMBO_1", "maxChargeRateKw": 150, "count": 4, "availableCount": 1, "outOfServiceCount": 1, "availabilityLastUpdateTime": "2026-01-02T03:04:05Z"},
+      {"type": "EV_CONNECTOR_TYPE_J1772", "maxChargeRateKw": 7.2, "count": 2}
+    ]
+  }
+}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL + "/v1"})
+	details, err := client.DetailsWithOptions(context.Background(), DetailsRequest{PlaceID: "place-123", IncludeEV: true})
+	if err != nil {
+		t.Fatalf("details error: %v", err)
+	}
+	ev := details.EVChargeOptions
+	if ev == nil || ev.ConnectorCount != 6 || len(ev.Connectors) != 2 {
+		t.Fatalf("unexpected ev options: %#v", ev)
+	}
+	fast := ev.Connectors[0]
+	if fast.Type != "EV_CONNECTOR_TYPE_CCS_COMBO_1" || fast.MaxChargeRateKW == nil || *fast.MaxChargeRateKW != 150 ||
+		fast.Count != 4 || fast.AvailableCount == nil || *fast.AvailableCount != 1 || fast.OutOfServiceCount == nil {
+		t.Fatalf("unexpected connector: %#v", fast)
+	}
+	if ev.Connectors[1].AvailableCount != nil {
+		t.Fatalf("expected unknown availability: %#v", ev.Connectors[1])
+	}
+	if mapEVChargeOptions(&evChargeOptionsPayload{}) != nil || mapEVChargeOptions(nil) != nil {
+		t.Fatalf("expected nil ev options for empty payloads")
+	}
+}
+
+func TestDetailsAddressComponents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{
+  "id": "place-123",
+  "addressComponents": [
+    {"longText": "Seattle", "shortText": "Seattle", "types": ["locality", "political"]},
+    {"longText": "Washington", "shortText": "WA", "types": ["administrative_area_level_1", "political"]}
+  ]
+}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL + "/v1"})
+	details, err := client.DetailsWithOptions(context.Background(), DetailsRequest{
+		PlaceID:                  "place-123",
+		IncludeAddressComponents: true,
+	})
+	if err != nil {
+		t.Fatalf("details error: %v", err)
+	}
+	if len(details.AddressComponents) != 2 {
+		t.Fatalf("expected 2 address components, got %#v", details.AddressComponents)
+	}
+	state := details.AddressComponents[1]
+	if state.LongText != "Washington" || state.ShortText != "WA" || state.Types[0] != "administrative_area_level_1" {
+		t.Fatalf("unexpected component: %#v", state)
+	}
+}
+
+func TestMapServiceOptions(t *testing.T) {
+	if mapServiceOptions(placeItem{ID: "p"}) != nil {
+		t.Fatalf("expected nil service options when none returned")
+	}
+	no := false
+	options := mapServiceOptions(placeItem{Takeout: &no})
+	if options == nil || options.Takeout == nil || *options.Takeout || options.DineIn != nil {
+		t.Fatalf("unexpected service options: %#v", options)
+	}
+}
+
+func TestDetailsAccessibility(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.Header.Get("X-Goog-FieldMask"), ",accessibilityOptions") {
+			t.Fatalf("expected accessibilityOptions in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+		}
+		_, _ = w.Write([]byte(`{
+  "id": "place-123",
+  "accessibilityOptions": {"wheelchairAccessibleEntrance": true, "wheelchairAccessibleRestroom": false}
+}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL + "/v1"})
+	details, err := client.DetailsWithOptions(context.Background(), DetailsRequest{PlaceID: "place-123", IncludeAccessibility: true})
+	if err != nil {
+		t.Fatalf("details error: %v", err)
+	}
+	access := details.Accessibility
+	if access == nil || access.WheelchairAccessibleEntrance == nil || !*access.WheelchairAccessibleEntrance ||
+		access.WheelchairAccessibleRestroom == nil || *access.WheelchairAccessibleRestroom || access.WheelchairAccessibleParking != nil {
+		t.Fatalf("unexpected accessibility: %#v", access)
+	}
+	if mapAccessibilityOptions(&accessibilityOptionsPayload{}) != nil {
+		t.Fatalf("expected nil accessibility when no option returned")
+	}
+}
+
+func TestDetailsAmenities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("X-Goog-FieldMask"), "servesBeer") || !strings.Contains(r.Header.Get("X-Goog-FieldMask"), "allowsDogs") {
+			t.Fatalf("expected amenities in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+		}
+		_, _ = w.Write([]byte(`{"id": "place-123", "servesBeer": true, "outdoorSeating": false, "allowsDogs": true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL + "/v1"})
+	details, err := client.DetailsWithOptions(context.Background(), DetailsRequest{PlaceID: "place-123", IncludeAmenities: true})
+	if err != nil {
+		t.Fatalf("details error: %v", err)
+	}
+	amenities := details.Amenities
+	if amenities == nil || amenities.ServesBeer == nil || !*amenities.ServesBeer || amenities.OutdoorSeating == nil ||
+		*amenities.OutdoorSeating || amenities.AllowsDogs == nil || amenities.ServesWine != nil {
+		t.Fatalf("unexpected amenities: %#v", amenities)
+	}
+	if mapAmenities(placeItem{ID: "p"}) != nil {
+		t.Fatalf("expected nil amenities when none returned")
+	}
 }
 
 func TestResolveSuccess(t *testing.T) {
@@ -566,6 +1276,56 @@ func TestResolveSuccess(t *testing.T) {
 	}
 }
 
+func TestReverse(t *testing.T) {
+	var gotRequest map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/places:searchNearby" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotRequest); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"places": [
+  {"id": "far", "formattedAddress": "3 Far St", "location": {"latitude": 47.6003, "longitude": -122.3300}},
+  {"id": "near", "displayName": {"text": "Corner Cafe"}, "formattedAddress": "1 Main St", "location": {"latitude": 47.6000, "longitude": -122.3301}},
+  {"id": "nowhere"}
+]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	place, err := client.ReverseWithOptions(context.Background(), ReverseRequest{Lat: 47.6, Lng: -122.33, Language: "de"})
+	if err != nil {
+		t.Fatalf("reverse error: %v", err)
+	}
+	if place.PlaceID != "near" || place.Address != "1 Main St" {
+		t.Fatalf("expected nearest place, got %#v", place)
+	}
+	if gotRequest["rankPreference"] != RankPreferenceDistance || gotRequest["languageCode"] != "de" {
+		t.Fatalf("unexpected request: %#v", gotRequest)
+	}
+	circle := gotRequest["locationRestriction"].(map[string]any)["circle"].(map[string]any)
+	if circle["radius"] != float64(reverseRadiusM) {
+		t.Fatalf("unexpected radius: %#v", circle["radius"])
+	}
+}
+
+func TestReverseNoPlaceAndValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	if _, err := client.Reverse(context.Background(), 1, 2); !errors.Is(err, ErrNoPlaceFound) {
+		t.Fatalf("expected ErrNoPlaceFound, got %v", err)
+	}
+	var validation ValidationError
+	if _, err := client.Reverse(context.Background(), 91, 0); !errors.As(err, &validation) || validation.Field != "lat" {
+		t.Fatalf("expected lat validation error, got %v", err)
+	}
+}
+
 func TestMissingAPIKey(t *testing.T) {
 	client := NewClient(Options{})
 	_, err := client.Search(context.Background(), SearchRequest{Query: "coffee"})
@@ -636,6 +1396,14 @@ func TestValidationErrors(t *testing.T) {
 		t.Fatalf("expected nearby limit error")
 	}
 
+	_, err = client.NearbySearch(context.Background(), NearbySearchRequest{
+		LocationRestriction: &LocationBias{Lat: 1, Lng: 2, RadiusM: 3},
+		MinRating:           &minRating,
+	})
+	if err == nil {
+		t.Fatalf("expected nearby min rating error")
+	}
+
 	_, err = client.PhotoMedia(context.Background(), PhotoMediaRequest{Name: ""})
 	if err == nil {
 		t.Fatalf("expected photo media name error")
@@ -647,6 +1415,63 @@ func TestValidationErrors(t *testing.T) {
 	}
 }
 
+func TestLocationBiasRectangle(t *testing.T) {
+	bias := &LocationBias{Rectangle: &BoundingBox{
+		SW: LatLng{Lat: 47.5, Lng: -122.5},
+		NE: LatLng{Lat: 47.7, Lng: -122.2},
+	}}
+	body := buildSearchBody(SearchRequest{Query: "coffee", Limit: 5, LocationBias: bias})
+	payload, err := json.Marshal(body["locationBias"])
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `{"rectangle":{"high":{"latitude":47.7,"longitude":-122.2},"low":{"latitude":47.5,"longitude":-122.5}}}`
+	if string(payload) != want {
+		t.Fatalf("unexpected rectangle payload: %s", payload)
+	}
+	if err := validateLocationBias(bias); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	var autocompleteBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&autocompleteBody); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"suggestions": []}`))
+	}))
+	defer server.Close()
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	if _, err := client.Autocomplete(context.Background(), AutocompleteRequest{Input: "cof", LocationBias: bias}); err != nil {
+		t.Fatalf("autocomplete error: %v", err)
+	}
+	if _, ok := autocompleteBody["locationBias"].(map[string]any)["rectangle"]; !ok {
+		t.Fatalf("expected rectangle in autocomplete body: %#v", autocompleteBody)
+	}
+
+	tests := []struct {
+		bias  *LocationBias
+		field string
+	}{
+		{&LocationBias{Rectangle: &BoundingBox{SW: LatLng{Lat: 48}, NE: LatLng{Lat: 47}}}, "location_bias.rectangle"},
+		{&LocationBias{Rectangle: &BoundingBox{SW: LatLng{Lat: -91}, NE: LatLng{Lat: 47}}}, "location_bias.rectangle.sw.lat"},
+		{&LocationBias{Rectangle: &BoundingBox{NE: LatLng{Lng: 181}}}, "location_bias.rectangle.ne.lng"},
+		{&LocationBias{RadiusM: 10, Rectangle: &BoundingBox{}}, "location_bias"},
+	}
+	for _, tt := range tests {
+		var validation ValidationError
+		if err := validateLocationBias(tt.bias); !errors.As(err, &validation) || validation.Field != tt.field {
+			t.Fatalf("expected %s validation error, got %v", tt.field, err)
+		}
+	}
+
+	err = validateNearbyRequest(NearbySearchRequest{LocationRestriction: bias, Limit: 5})
+	var validation ValidationError
+	if !errors.As(err, &validation) || validation.Field != "location_restriction" {
+		t.Fatalf("expected nearby to reject rectangles, got %v", err)
+	}
+}
+
 func TestBuildSearchBodyOmitsEmptyPriceLevels(t *testing.T) {
 	request := SearchRequest{Query: "coffee", Filters: &Filters{PriceLevels: []int{9}}}
 	body := buildSearchBody(request)
@@ -659,6 +1484,47 @@ func TestBuildSearchBodyOmitsEmptyPriceLevels(t *testing.T) {
 	}
 }
 
+func TestBuildSearchBodyKeywordMode(t *testing.T) {
+	filters := &Filters{Keyword: "vegan"}
+	tests := []struct {
+		mode, want string
+	}{
+		{"", "pizza vegan"},
+		{KeywordModeAppend, "pizza vegan"},
+		{"separate", "pizza"},
+	}
+	for _, tt := range tests {
+		request := applySearchDefaults(SearchRequest{Query: "pizza", Filters: filters, KeywordMode: tt.mode})
+		if err := validateSearchRequest(request); err != nil {
+			t.Fatalf("mode %q: unexpected error: %v", tt.mode, err)
+		}
+		if got := buildSearchBody(request)["textQuery"]; got != tt.want {
+			t.Fatalf("mode %q: textQuery = %#v, want %q", tt.mode, got, tt.want)
+		}
+	}
+	if filters.Keyword != "vegan" {
+		t.Fatalf("keyword was modified: %q", filters.Keyword)
+	}
+
+	var validation ValidationError
+	err := validateSearchRequest(applySearchDefaults(SearchRequest{Query: "pizza", KeywordMode: "prepend"}))
+	if !errors.As(err, &validation) || validation.Field != "keyword_mode" {
+		t.Fatalf("expected keyword_mode validation error, got %v", err)
+	}
+}
+
+func TestSearchRejectsMultipleTypes(t *testing.T) {
+	client := NewClient(Options{APIKey: "test-key", BaseURL: "http://example.com"})
+	_, err := client.Search(context.Background(), SearchRequest{
+		Query:   "food",
+		Filters: &Filters{Types: []string{"cafe", "bakery"}},
+	})
+	var validation ValidationError
+	if !errors.As(err, &validation) || validation.Field != "filters.types" {
+		t.Fatalf("expected filters.types validation error, got %v", err)
+	}
+}
+
 func TestMappingHelpers(t *testing.T) {
 	if mapLatLng(nil) != nil {
 		t.Fatalf("expected nil location")
@@ -676,3 +1542,134 @@ func TestMappingHelpers(t *testing.T) {
 		t.Fatalf("expected nil price level")
 	}
 }
+
+func TestMapPriceRange(t *testing.T) {
+	var payload priceRangePayload
+	raw := `{"startPrice": {"currencyCode": "USD", "units": "10"}, "endPrice": {"currencyCode": "USD", "units": "20", "nanos": 500000000}}`
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	got := mapPriceRange(&payload)
+	if got == nil || got.CurrencyCode != "USD" || got.Start == nil || *got.Start != 10 || got.End == nil || *got.End != 20.5 {
+		t.Fatalf("unexpected price range: %+v", got)
+	}
+
+	openEnded := mapPriceRange(&priceRangePayload{StartPrice: &moneyPayload{CurrencyCode: "EUR", Nanos: 750000000}})
+	if openEnded == nil || openEnded.End != nil || *openEnded.Start != 0.75 || openEnded.CurrencyCode != "EUR" {
+		t.Fatalf("unexpected open-ended range: %+v", openEnded)
+	}
+	if mapPriceRange(&priceRangePayload{StartPrice: &moneyPayload{Units: "ten"}}) != nil {
+		t.Fatalf("expected nil for malformed units")
+	}
+	if mapPriceRange(nil) != nil {
+		t.Fatalf("expected nil without a price range")
+	}
+}
+
+func TestProbePlaces(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/places:searchText" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("X-Goog-FieldMask") != probeFieldMask {
+			t.Fatalf("unexpected field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": {"status": "PERMISSION_DENIED"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, RoutesBaseURL: server.URL})
+	result, err := client.ProbePlaces(context.Background())
+	if err != nil {
+		t.Fatalf("probe error: %v", err)
+	}
+	if result.StatusCode != http.StatusForbidden {
+		t.Fatalf("unexpected status: %d", result.StatusCode)
+	}
+	if result.ServerTime.IsZero() {
+		t.Fatalf("expected server time from Date header")
+	}
+	if encoded, _ := json.Marshal(ProbeResult{}); bytes.Contains(encoded, []byte("server_time")) {
+		t.Fatalf("expected a zero server time to be omitted: %s", encoded)
+	}
+
+	if _, err := NewClient(Options{BaseURL: server.URL}).ProbePlaces(context.Background()); !errors.Is(err, ErrMissingAPIKey) {
+		t.Fatalf("expected missing api key, got %v", err)
+	}
+}
+
+func TestProbeRoutes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Goog-Api-Key") != "" {
+			t.Fatalf("routes probe must not send the api key")
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", RoutesBaseURL: server.URL})
+	result, err := client.ProbeRoutes(context.Background())
+	if err != nil {
+		t.Fatalf("probe error: %v", err)
+	}
+	if result.StatusCode != http.StatusNotFound {
+		t.Fatalf("unexpected status: %d", result.StatusCode)
+	}
+
+	server.Close()
+	if _, err := client.ProbeRoutes(context.Background()); err == nil {
+		t.Fatalf("expected unreachable error")
+	}
+}
+
+func TestCustomHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Debug") != "1" {
+			t.Fatalf("missing custom header")
+		}
+		if r.Header.Get("Accept") != "application/json" {
+			t.Fatalf("unexpected accept header: %s", r.Header.Get("Accept"))
+		}
+		if r.Header.Get("X-Goog-Api-Key") != "test-key" {
+			t.Fatalf("api key header was overridden: %s", r.Header.Get("X-Goog-Api-Key"))
+		}
+		if r.Header.Get("X-Goog-FieldMask") != searchFieldMask {
+			t.Fatalf("unexpected field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+		}
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	headers := http.Header{}
+	headers.Set("X-Debug", "1")
+	headers.Set("Accept", "application/json")
+	headers.Set("x-goog-api-key", "stolen")
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, Headers: headers})
+	if _, err := client.Search(context.Background(), SearchRequest{Query: "coffee"}); err != nil {
+		t.Fatalf("search error: %v", err)
+	}
+}
+
+func TestRequestHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte("nope"))
+	}))
+	defer server.Close()
+
+	var infos []RequestInfo
+	client := NewClient(Options{
+		APIKey:      "test-key",
+		BaseURL:     server.URL,
+		RequestHook: func(info RequestInfo) { infos = append(infos, info) },
+	})
+	_, _ = client.Search(context.Background(), SearchRequest{Query: "coffee"})
+	if len(infos) != 1 {
+		t.Fatalf("expected 1 hook call, got %d", len(infos))
+	}
+	info := infos[0]
+	if info.Method != http.MethodPost || info.URL != server.URL+"/places:searchText" || info.StatusCode != http.StatusTeapot {
+		t.Fatalf("unexpected request info: %#v", info)
+	}
+}
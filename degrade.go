@@ -0,0 +1,68 @@
+package goplaces
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// deniedField reports which field in fieldMask a 403 PERMISSION_DENIED
+// names, so the request can be retried without it. Only field violations
+// and messages that talk about fields count; a plain key or API-disabled
+// 403 never drops anything. id and nextPageToken are never dropped. The
+// longest match wins, so "userRatingCount" beats "rating".
+func deniedField(err error, fieldMask string) (string, bool) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		return "", false
+	}
+	var body googleErrorBody
+	if json.Unmarshal([]byte(apiErr.Body), &body) != nil || body.Error.Status != "PERMISSION_DENIED" {
+		return "", false
+	}
+
+	var texts []string
+	if strings.Contains(strings.ToLower(body.Error.Message), "field") {
+		texts = append(texts, body.Error.Message)
+	}
+	for _, detail := range body.Error.Details {
+		for _, violation := range detail.FieldViolations {
+			texts = append(texts, violation.Field, violation.Description)
+		}
+	}
+
+	var denied string
+	for _, field := range strings.Split(fieldMask, ",") {
+		name := strings.TrimPrefix(field, "places.")
+		if name == "id" || name == "nextPageToken" || len(name) <= len(denied) {
+			continue
+		}
+		for _, text := range texts {
+			if mentionsField(text, name) {
+				denied = field
+				break
+			}
+		}
+	}
+	return denied, denied != ""
+}
+
+// withoutField removes one entry from a comma-separated field mask.
+func withoutField(fieldMask, field string) string {
+	fields := strings.Split(fieldMask, ",")
+	kept := fields[:0]
+	for _, entry := range fields {
+		if entry != field {
+			kept = append(kept, entry)
+		}
+	}
+	return strings.Join(kept, ",")
+}
+
+func (c *Client) warnf(format string, args ...any) {
+	if c.warn != nil {
+		c.warn(fmt.Sprintf(format, args...))
+	}
+}
@@ -0,0 +1,74 @@
+package goplaces
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDetailsDropsDeniedField(t *testing.T) {
+	var masks []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mask := r.Header.Get("X-Goog-FieldMask")
+		masks = append(masks, mask)
+		if strings.Contains(mask, "reviews") {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": {"code": 403, "status": "PERMISSION_DENIED",
+  "message": "The field 'reviews' is not permitted for this API key."}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "abc", "displayName": {"text": "Cafe"}}`))
+	}))
+	defer server.Close()
+
+	var warnings []string
+	client := NewClient(Options{
+		APIKey:  "test-key",
+		BaseURL: server.URL,
+		Warn:    func(message string) { warnings = append(warnings, message) },
+	})
+	details, err := client.DetailsWithOptions(context.Background(), DetailsRequest{PlaceID: "abc", IncludeReviews: true})
+	if err != nil {
+		t.Fatalf("details error: %v", err)
+	}
+	if details.Name != "Cafe" {
+		t.Fatalf("unexpected details: %#v", details)
+	}
+	if len(masks) != 2 || masks[1] != detailsFieldMaskBase {
+		t.Fatalf("expected one retry without reviews, got %q", masks)
+	}
+	if len(warnings) != 1 || warnings[0] != "dropped field reviews (not permitted)" {
+		t.Fatalf("unexpected warnings: %q", warnings)
+	}
+}
+
+func TestDeniedField(t *testing.T) {
+	denied := func(body string) error {
+		return &APIError{StatusCode: http.StatusForbidden, Body: body}
+	}
+	mask := "places.id,places.rating,places.userRatingCount,nextPageToken"
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"longest match", denied(`{"error": {"status": "PERMISSION_DENIED", "message": "Field userRatingCount is not permitted."}}`), "places.userRatingCount"},
+		{"violation", denied(`{"error": {"status": "PERMISSION_DENIED", "details": [{"fieldViolations": [{"field": "rating"}]}]}}`), "places.rating"},
+		{"key error", denied(`{"error": {"status": "PERMISSION_DENIED", "message": "Requests with rating are blocked."}}`), ""},
+		{"id kept", denied(`{"error": {"status": "PERMISSION_DENIED", "message": "Field id is not permitted."}}`), ""},
+		{"not 403", &APIError{StatusCode: http.StatusBadRequest, Body: `{"error": {"status": "PERMISSION_DENIED", "message": "field rating"}}`}, ""},
+		{"other error", errors.New("boom"), ""},
+	}
+	for _, tc := range tests {
+		got, ok := deniedField(tc.err, mask)
+		if got != tc.want || ok != (tc.want != "") {
+			t.Fatalf("%s: got %q, %v; want %q", tc.name, got, ok, tc.want)
+		}
+	}
+	if got := withoutField(mask, "places.rating"); got != "places.id,places.userRatingCount,nextPageToken" {
+		t.Fatalf("unexpected mask: %s", got)
+	}
+}
@@ -0,0 +1,204 @@
+package goplaces
+
+import (
+	"net/http"
+	"strings"
+)
+
+// RequestDescription is the HTTP call a Client method would make, for dry
+// runs and debugging. It never holds the API key, which is only ever sent
+// as a header.
+type RequestDescription struct {
+	Method    string         `json:"method"`
+	URL       string         `json:"url"`
+	FieldMask string         `json:"field_mask,omitempty"`
+	Body      map[string]any `json:"body,omitempty"`
+}
+
+// DescribeSearch returns the first request Search would send for req,
+// after the same defaults and validation, without sending it.
+func (c *Client) DescribeSearch(req SearchRequest) (RequestDescription, error) {
+	req = applySearchDefaults(req)
+	if err := validateSearchRequest(req); err != nil {
+		return RequestDescription{}, err
+	}
+	if err := validateFields(req.Fields, searchFields); err != nil {
+		return RequestDescription{}, err
+	}
+	language, region, err := c.locale(req.Language, req.Region)
+	if err != nil {
+		return RequestDescription{}, err
+	}
+	req.Language, req.Region = language, region
+	endpoint, err := c.buildURL("/places:searchText", nil)
+	if err != nil {
+		return RequestDescription{}, err
+	}
+	return RequestDescription{
+		Method:    http.MethodPost,
+		URL:       endpoint,
+		FieldMask: searchFieldMaskForRequest(req),
+		Body:      buildSearchBody(req),
+	}, nil
+}
+
+// DescribeNearbySearch returns the request NearbySearch would send.
+func (c *Client) DescribeNearbySearch(req NearbySearchRequest) (RequestDescription, error) {
+	req = applyNearbyDefaults(req)
+	if err := validateNearbyRequest(req); err != nil {
+		return RequestDescription{}, err
+	}
+	language, region, err := c.locale(req.Language, req.Region)
+	if err != nil {
+		return RequestDescription{}, err
+	}
+	req.Language, req.Region = language, region
+	endpoint, err := c.buildURL("/places:searchNearby", nil)
+	if err != nil {
+		return RequestDescription{}, err
+	}
+	return RequestDescription{
+		Method:    http.MethodPost,
+		URL:       endpoint,
+		FieldMask: nearbyFieldMask,
+		Body:      buildNearbyBody(req),
+	}, nil
+}
+
+// DescribeDetails returns the request DetailsWithOptions would send.
+func (c *Client) DescribeDetails(req DetailsRequest) (RequestDescription, error) {
+	if strings.TrimSpace(req.PlaceID) == "" {
+		return RequestDescription{}, ValidationError{Field: "place_id", Message: "required"}
+	}
+	if err := validateFields(req.Fields, detailsFields); err != nil {
+		return RequestDescription{}, err
+	}
+	language, region, err := c.locale(req.Language, req.Region)
+	if err != nil {
+		return RequestDescription{}, err
+	}
+	req.Language, req.Region = language, region
+	endpoint, err := c.detailsEndpoint(req)
+	if err != nil {
+		return RequestDescription{}, err
+	}
+	return RequestDescription{
+		Method:    http.MethodGet,
+		URL:       endpoint,
+		FieldMask: detailsFieldMaskForRequest(req),
+	}, nil
+}
+
+// DescribeAutocomplete returns the request Autocomplete would send. Only
+// an explicit SessionToken appears: a generated one (AutoSessionToken) is
+// fresh per call.
+func (c *Client) DescribeAutocomplete(req AutocompleteRequest) (RequestDescription, error) {
+	req = applyAutocompleteDefaults(req)
+	if err := validateAutocompleteRequest(req); err != nil {
+		return RequestDescription{}, err
+	}
+	language, region, err := c.locale(req.Language, req.Region)
+	if err != nil {
+		return RequestDescription{}, err
+	}
+	req.Language, req.Region = language, region
+	endpoint, err := c.buildURL("/places:autocomplete", nil)
+	if err != nil {
+		return RequestDescription{}, err
+	}
+	return RequestDescription{
+		Method:    http.MethodPost,
+		URL:       endpoint,
+		FieldMask: autocompleteFieldMask,
+		Body:      buildAutocompleteBody(req, strings.TrimSpace(req.SessionToken)),
+	}, nil
+}
+
+// DescribeResolve returns the request Resolve would send.
+func (c *Client) DescribeResolve(req LocationResolveRequest) (RequestDescription, error) {
+	req = applyResolveDefaults(req)
+	if err := validateResolveRequest(req); err != nil {
+		return RequestDescription{}, err
+	}
+	language, region, err := c.locale(req.Language, req.Region)
+	if err != nil {
+		return RequestDescription{}, err
+	}
+	req.Language, req.Region = language, region
+	endpoint, err := c.buildURL("/places:searchText", nil)
+	if err != nil {
+		return RequestDescription{}, err
+	}
+	return RequestDescription{
+		Method:    http.MethodPost,
+		URL:       endpoint,
+		FieldMask: resolveFieldMask,
+		Body:      buildResolveBody(req),
+	}, nil
+}
+
+// DescribeReverse returns the request ReverseWithOptions would send.
+func (c *Client) DescribeReverse(req ReverseRequest) (RequestDescription, error) {
+	if err := validateReverseRequest(req); err != nil {
+		return RequestDescription{}, err
+	}
+	language, region, err := c.locale(req.Language, req.Region)
+	if err != nil {
+		return RequestDescription{}, err
+	}
+	req.Language, req.Region = language, region
+	endpoint, err := c.buildURL("/places:searchNearby", nil)
+	if err != nil {
+		return RequestDescription{}, err
+	}
+	return RequestDescription{
+		Method:    http.MethodPost,
+		URL:       endpoint,
+		FieldMask: reverseFieldMask,
+		Body:      buildReverseBody(req),
+	}, nil
+}
+
+// DescribeRoute returns the computeRoutes request Route starts with. The
+// per-waypoint searches that follow depend on the route returned, so they
+// cannot be described up front.
+func (c *Client) DescribeRoute(req RouteRequest) (RequestDescription, error) {
+	req = applyRouteDefaults(req)
+	if err := validateRouteRequest(req); err != nil {
+		return RequestDescription{}, err
+	}
+	return RequestDescription{
+		Method:    http.MethodPost,
+		URL:       c.routesBaseURL + routesPath,
+		FieldMask: routesFieldMask,
+		Body:      buildRouteBody(req),
+	}, nil
+}
+
+// DescribeProbePlaces returns the request ProbePlaces would send.
+func (c *Client) DescribeProbePlaces() (RequestDescription, error) {
+	endpoint, err := c.buildURL("/places:searchText", nil)
+	if err != nil {
+		return RequestDescription{}, err
+	}
+	return RequestDescription{
+		Method:    http.MethodPost,
+		URL:       endpoint,
+		FieldMask: probeFieldMask,
+		Body:      probePlacesBody(),
+	}, nil
+}
+
+// DescribeProbeRoutes returns the request ProbeRoutes would send.
+func (c *Client) DescribeProbeRoutes() RequestDescription {
+	return RequestDescription{Method: http.MethodGet, URL: c.routesBaseURL + "/"}
+}
+
+// DescribePhotoMedia returns the request PhotoMedia would send.
+func (c *Client) DescribePhotoMedia(req PhotoMediaRequest) (RequestDescription, error) {
+	endpoint, err := c.photoMediaEndpoint(req)
+	if err != nil {
+		return RequestDescription{}, err
+	}
+	return RequestDescription{Method: http.MethodGet, URL: endpoint}, nil
+}
@@ -0,0 +1,58 @@
+package goplaces
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestDescribeSearch(t *testing.T) {
+	client := NewClient(Options{APIKey: "secret-key", BaseURL: "https://example.test/v1"})
+	got, err := client.DescribeSearch(SearchRequest{Query: "coffee", Language: "de", Fields: []string{"rating"}})
+	if err != nil {
+		t.Fatalf("DescribeSearch error: %v", err)
+	}
+	if got.Method != http.MethodPost || got.URL != "https://example.test/v1/places:searchText" {
+		t.Fatalf("unexpected endpoint: %s %s", got.Method, got.URL)
+	}
+	if got.FieldMask != "places.id,places.rating,nextPageToken" {
+		t.Fatalf("unexpected field mask: %s", got.FieldMask)
+	}
+	if got.Body["textQuery"] != "coffee" || got.Body["languageCode"] != "de" || got.Body["pageSize"] != defaultSearchLimit {
+		t.Fatalf("unexpected body: %#v", got.Body)
+	}
+
+	var validation ValidationError
+	if _, err := client.DescribeSearch(SearchRequest{}); !errors.As(err, &validation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestDescribeDetails(t *testing.T) {
+	client := NewClient(Options{APIKey: "secret-key", BaseURL: "https://example.test/v1"})
+	got, err := client.DescribeDetails(DetailsRequest{PlaceID: "abc", Region: "US"})
+	if err != nil {
+		t.Fatalf("DescribeDetails error: %v", err)
+	}
+	if got.Method != http.MethodGet || got.URL != "https://example.test/v1/places/abc?regionCode=US" {
+		t.Fatalf("unexpected endpoint: %s %s", got.Method, got.URL)
+	}
+	if got.FieldMask != detailsFieldMaskBase || got.Body != nil {
+		t.Fatalf("unexpected description: %+v", got)
+	}
+}
+
+func TestDescribeRoute(t *testing.T) {
+	client := NewClient(Options{APIKey: "secret-key", RoutesBaseURL: "https://routes.example.test"})
+	got, err := client.DescribeRoute(RouteRequest{Query: "coffee", From: "Seattle", To: "placeId:abc", Via: []string{"Tacoma"}})
+	if err != nil {
+		t.Fatalf("DescribeRoute error: %v", err)
+	}
+	if got.URL != "https://routes.example.test"+routesPath || got.FieldMask != routesFieldMask {
+		t.Fatalf("unexpected description: %+v", got)
+	}
+	destination, _ := got.Body["destination"].(map[string]any)
+	if got.Body["travelMode"] != travelModeDrive || destination["placeId"] != "abc" || got.Body["intermediates"] == nil {
+		t.Fatalf("unexpected body: %#v", got.Body)
+	}
+}
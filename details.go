@@ -4,14 +4,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"strings"
 )
 
 const (
-	detailsFieldMaskBase   = "id,displayName,formattedAddress,location,rating,priceLevel,types,regularOpeningHours,currentOpeningHours,nationalPhoneNumber,websiteUri"
+	detailsFieldMaskBase   = "id,displayName,formattedAddress,location,rating,userRatingCount,priceLevel,priceRange,types,regularOpeningHours,currentOpeningHours,businessStatus,utcOffsetMinutes,plusCode,nationalPhoneNumber,internationalPhoneNumber,websiteUri"
 	detailsFieldMaskReview = "reviews"
 	detailsFieldMaskPhotos = "photos"
+	// Service options bill at the Atmosphere tier.
+	detailsFieldMaskServices = "dineIn,takeout,delivery,curbsidePickup,reservable"
+	detailsFieldMaskAddress  = "addressComponents"
+	detailsFieldMaskViewport = "viewport"
+	detailsFieldMaskShort    = "shortFormattedAddress"
+	detailsFieldMaskEV       = "evChargeOptions"
+	detailsFieldMaskAccess   = "accessibilityOptions"
+	// Amenities bill at the Atmosphere tier, like service options.
+	detailsFieldMaskAmenities = "servesBeer,servesWine,servesCocktails,servesBreakfast,servesBrunch,servesLunch,servesDinner,servesVegetarianFood,outdoorSeating,liveMusic,menuForChildren,allowsDogs"
 )
 
 // Details fetches details for a specific place ID.
@@ -21,20 +29,14 @@ func (c *Client) Details(ctx context.Context, placeID string) (PlaceDetails, err
 
 // DetailsWithOptions fetches place details with locale hints.
 func (c *Client) DetailsWithOptions(ctx context.Context, req DetailsRequest) (PlaceDetails, error) {
-	placeID := strings.TrimSpace(req.PlaceID)
-	if placeID == "" {
+	if strings.TrimSpace(req.PlaceID) == "" {
 		return PlaceDetails{}, ValidationError{Field: "place_id", Message: "required"}
 	}
-
-	endpoint, err := c.buildURL("/places/"+placeID, map[string]string{
-		"languageCode": strings.TrimSpace(req.Language),
-		"regionCode":   strings.TrimSpace(req.Region),
-	})
-	if err != nil {
+	if err := validateFields(req.Fields, detailsFields); err != nil {
 		return PlaceDetails{}, err
 	}
 
-	payload, err := c.doRequest(ctx, http.MethodGet, endpoint, nil, detailsFieldMaskForRequest(req))
+	payload, err := c.detailsPayload(ctx, req)
 	if err != nil {
 		return PlaceDetails{}, err
 	}
@@ -47,8 +49,34 @@ func (c *Client) DetailsWithOptions(ctx context.Context, req DetailsRequest) (Pl
 	return mapPlaceDetails(place), nil
 }
 
+func (c *Client) detailsPayload(ctx context.Context, req DetailsRequest) ([]byte, error) {
+	language, region, err := c.locale(req.Language, req.Region)
+	if err != nil {
+		return nil, err
+	}
+	req.Language, req.Region = language, region
+	endpoint, err := c.detailsEndpoint(req)
+	if err != nil {
+		return nil, err
+	}
+	fieldMask := detailsFieldMaskForRequest(req)
+	return c.cachedGet(ctx, "details|"+fieldMask+"|"+endpoint, endpoint, fieldMask)
+}
+
+// detailsEndpoint is the GET URL for req; details pass the locale as query
+// parameters rather than a body.
+func (c *Client) detailsEndpoint(req DetailsRequest) (string, error) {
+	return c.buildURL("/places/"+strings.TrimSpace(req.PlaceID), map[string]string{
+		"languageCode": strings.TrimSpace(req.Language),
+		"regionCode":   strings.TrimSpace(req.Region),
+	})
+}
+
 func detailsFieldMaskForRequest(req DetailsRequest) string {
 	fields := []string{detailsFieldMaskBase}
+	if len(req.Fields) > 0 {
+		fields = []string{customFieldMask(req.Fields, "")}
+	}
 	if req.IncludeReviews {
 		// Reviews are heavy; opt-in to include them.
 		fields = append(fields, detailsFieldMaskReview)
@@ -56,23 +84,59 @@ func detailsFieldMaskForRequest(req DetailsRequest) string {
 	if req.IncludePhotos {
 		fields = append(fields, detailsFieldMaskPhotos)
 	}
+	if req.IncludeServices {
+		fields = append(fields, detailsFieldMaskServices)
+	}
+	if req.IncludeAddressComponents {
+		fields = append(fields, detailsFieldMaskAddress)
+	}
+	if req.IncludeViewport {
+		fields = append(fields, detailsFieldMaskViewport)
+	}
+	if req.IncludeShortAddress {
+		fields = append(fields, detailsFieldMaskShort)
+	}
+	if req.IncludeEV {
+		fields = append(fields, detailsFieldMaskEV)
+	}
+	if req.IncludeAccessibility {
+		fields = append(fields, detailsFieldMaskAccess)
+	}
+	if req.IncludeAmenities {
+		fields = append(fields, detailsFieldMaskAmenities)
+	}
 	return strings.Join(fields, ",")
 }
 
 func mapPlaceDetails(place placeItem) PlaceDetails {
 	return PlaceDetails{
-		PlaceID:    place.ID,
-		Name:       displayName(place.DisplayName),
-		Address:    place.FormattedAddress,
-		Location:   mapLatLng(place.Location),
-		Rating:     place.Rating,
-		PriceLevel: mapPriceLevel(place.PriceLevel),
-		Types:      place.Types,
-		Phone:      place.NationalPhoneNumber,
-		Website:    place.WebsiteURI,
-		Hours:      weekdayDescriptions(place.RegularOpeningHours),
-		OpenNow:    openNow(place.CurrentOpeningHours),
-		Reviews:    mapReviews(place.Reviews),
-		Photos:     mapPhotos(place.Photos),
+		PlaceID:            place.ID,
+		Name:               displayName(place.DisplayName),
+		NameLanguage:       displayNameLanguage(place.DisplayName),
+		Address:            place.FormattedAddress,
+		ShortAddress:       place.ShortFormattedAddress,
+		Location:           mapLatLng(place.Location),
+		Rating:             place.Rating,
+		UserRatingCount:    place.UserRatingCount,
+		PriceLevel:         mapPriceLevel(place.PriceLevel),
+		PriceRange:         mapPriceRange(place.PriceRange),
+		Types:              place.Types,
+		Phone:              place.NationalPhoneNumber,
+		InternationalPhone: place.InternationalPhoneNumber,
+		Website:            place.WebsiteURI,
+		Hours:              weekdayDescriptions(place.RegularOpeningHours),
+		Periods:            openingPeriods(place.RegularOpeningHours),
+		OpenNow:            openNow(place.CurrentOpeningHours),
+		BusinessStatus:     place.BusinessStatus,
+		UTCOffsetMinutes:   place.UTCOffsetMinutes,
+		Reviews:            mapReviews(place.Reviews),
+		Photos:             mapPhotos(place.Photos),
+		Services:           mapServiceOptions(place),
+		Amenities:          mapAmenities(place),
+		Accessibility:      mapAccessibilityOptions(place.AccessibilityOptions),
+		AddressComponents:  mapAddressComponents(place.AddressComponents),
+		Viewport:           mapViewport(place.Viewport),
+		EVChargeOptions:    mapEVChargeOptions(place.EVChargeOptions),
+		PlusCode:           mapPlusCode(place.PlusCode),
 	}
 }
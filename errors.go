@@ -5,6 +5,9 @@ import "fmt"
 // ErrMissingAPIKey indicates a missing API key.
 var ErrMissingAPIKey = fmt.Errorf("goplaces: missing api key")
 
+// ErrNoPlaceFound is returned by Reverse when no place is near enough.
+var ErrNoPlaceFound = fmt.Errorf("goplaces: no place found")
+
 // ValidationError describes an invalid request payload.
 type ValidationError struct {
 	Field   string
@@ -19,11 +22,21 @@ func (e ValidationError) Error() string {
 type APIError struct {
 	StatusCode int
 	Body       string
+	// Method and Endpoint name the failed request. Endpoint is the path
+	// below the base URL, e.g. "places:searchText" or "places/<id>".
+	Method   string
+	Endpoint string
 }
 
 func (e *APIError) Error() string {
+	if e.Endpoint == "" {
+		if e.Body == "" {
+			return fmt.Sprintf("goplaces: api error (%d)", e.StatusCode)
+		}
+		return fmt.Sprintf("goplaces: api error (%d): %s", e.StatusCode, e.Body)
+	}
 	if e.Body == "" {
-		return fmt.Sprintf("goplaces: api error (%d)", e.StatusCode)
+		return fmt.Sprintf("goplaces: %s %d", e.Endpoint, e.StatusCode)
 	}
-	return fmt.Sprintf("goplaces: api error (%d): %s", e.StatusCode, e.Body)
+	return fmt.Sprintf("goplaces: %s %d: %s", e.Endpoint, e.StatusCode, e.Body)
 }
@@ -20,4 +20,9 @@ func TestErrorMessages(t *testing.T) {
 	if !strings.Contains(apiErr.Error(), "nope") {
 		t.Fatalf("unexpected api error: %s", apiErr.Error())
 	}
+
+	apiErr = &APIError{StatusCode: 503, Method: "POST", Endpoint: "directions/v2:computeRoutes"}
+	if apiErr.Error() != "goplaces: directions/v2:computeRoutes 503" {
+		t.Fatalf("unexpected api error: %s", apiErr.Error())
+	}
 }
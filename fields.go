@@ -0,0 +1,74 @@
+package goplaces
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// searchFields are the Place fields PlaceSummary surfaces. SearchRequest.Fields
+// is limited to these so every requested field shows up in the results.
+var searchFields = []string{
+	"id", "displayName", "formattedAddress", "location", "rating", "userRatingCount",
+	"priceLevel", "priceRange", "types", "currentOpeningHours", "businessStatus",
+}
+
+// detailsFields are the Place fields PlaceDetails surfaces.
+var detailsFields = []string{
+	"id", "displayName", "formattedAddress", "shortFormattedAddress", "location", "rating",
+	"userRatingCount", "priceLevel", "priceRange", "types", "regularOpeningHours", "currentOpeningHours",
+	"businessStatus", "utcOffsetMinutes", "plusCode", "nationalPhoneNumber",
+	"internationalPhoneNumber", "websiteUri", "reviews", "photos", "addressComponents",
+	"viewport", "evChargeOptions", "accessibilityOptions", "dineIn", "takeout", "delivery",
+	"curbsidePickup", "reservable", "servesBeer", "servesWine", "servesCocktails",
+	"servesBreakfast", "servesBrunch", "servesLunch", "servesDinner", "servesVegetarianFood",
+	"outdoorSeating", "liveMusic", "menuForChildren", "allowsDogs",
+}
+
+// validateFields rejects names outside known, so a typo fails here rather
+// than as a 400 from the API.
+func validateFields(fields, known []string) error {
+	for _, field := range fields {
+		if !slices.Contains(known, strings.TrimSpace(field)) {
+			return ValidationError{
+				Field:   "fields",
+				Message: fmt.Sprintf("unknown field %q (known: %s)", field, strings.Join(known, ", ")),
+			}
+		}
+	}
+	return nil
+}
+
+// customFieldMask builds a mask from user fields, always including id and
+// prefixing each name (e.g. "places.").
+func customFieldMask(fields []string, prefix string) string {
+	mask := []string{prefix + "id"}
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if entry := prefix + field; !slices.Contains(mask, entry) {
+			mask = append(mask, entry)
+		}
+	}
+	return strings.Join(mask, ",")
+}
+
+// FieldMask returns the X-Goog-FieldMask Search sends for r, so callers can
+// see which (billed) fields a request asks for.
+func (r SearchRequest) FieldMask() string {
+	return searchFieldMaskForRequest(r)
+}
+
+// FieldMask returns the X-Goog-FieldMask NearbySearch sends.
+func (r NearbySearchRequest) FieldMask() string {
+	return nearbyFieldMask
+}
+
+// FieldMask returns the X-Goog-FieldMask DetailsWithOptions sends for r.
+func (r DetailsRequest) FieldMask() string {
+	return detailsFieldMaskForRequest(r)
+}
+
+// FieldMask returns the X-Goog-FieldMask Autocomplete sends.
+func (r AutocompleteRequest) FieldMask() string {
+	return autocompleteFieldMask
+}
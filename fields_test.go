@@ -0,0 +1,54 @@
+package goplaces
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchCustomFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Goog-FieldMask"); got != "places.id,places.displayName,places.rating,nextPageToken" {
+			t.Fatalf("unexpected field mask: %s", got)
+		}
+		_, _ = w.Write([]byte(`{"places": [{"id": "abc", "displayName": {"text": "Cafe"}, "rating": 4.5}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	response, err := client.Search(context.Background(), SearchRequest{Query: "coffee", Fields: []string{"displayName", " rating", "id"}})
+	if err != nil {
+		t.Fatalf("search error: %v", err)
+	}
+	if len(response.Results) != 1 || response.Results[0].Rating == nil || *response.Results[0].Rating != 4.5 {
+		t.Fatalf("unexpected results: %#v", response.Results)
+	}
+}
+
+func TestDetailsCustomFields(t *testing.T) {
+	req := DetailsRequest{PlaceID: "p", Fields: []string{"websiteUri"}, IncludeReviews: true}
+	if got := detailsFieldMaskForRequest(req); got != "id,websiteUri,reviews" {
+		t.Fatalf("unexpected field mask: %s", got)
+	}
+}
+
+func TestCustomFieldsValidation(t *testing.T) {
+	client := NewClient(Options{APIKey: "test-key", BaseURL: "http://127.0.0.1:0"})
+	var validation ValidationError
+
+	_, err := client.Search(context.Background(), SearchRequest{Query: "coffee", Fields: []string{"ratng"}})
+	if !errors.As(err, &validation) || validation.Field != "fields" {
+		t.Fatalf("expected fields validation error, got %v", err)
+	}
+	// Search results cannot surface details-only fields.
+	_, err = client.Search(context.Background(), SearchRequest{Query: "coffee", Fields: []string{"websiteUri"}})
+	if !errors.As(err, &validation) {
+		t.Fatalf("expected fields validation error, got %v", err)
+	}
+	_, err = client.DetailsWithOptions(context.Background(), DetailsRequest{PlaceID: "p", Fields: []string{"website"}})
+	if !errors.As(err, &validation) || validation.Field != "fields" {
+		t.Fatalf("expected fields validation error, got %v", err)
+	}
+}
@@ -3,3 +3,10 @@ module github.com/steipete/goplaces
 go 1.25.5
 
 require github.com/alecthomas/kong v1.13.0
+
+require (
+	golang.org/x/term v0.45.0
+	golang.org/x/time v0.15.0
+)
+
+require golang.org/x/sys v0.47.0 // indirect
@@ -0,0 +1,38 @@
+package goplaces
+
+import "time"
+
+const (
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+)
+
+// IsOpenAt reports whether the regular opening hours cover t. t is converted
+// to the place's local time with UTCOffsetMinutes when known (the current
+// offset, so answers across a DST change can be off by an hour); otherwise
+// t's own wall clock is used. Places without Periods are reported closed.
+func (p PlaceDetails) IsOpenAt(t time.Time) bool {
+	if p.UTCOffsetMinutes != nil {
+		t = t.UTC().Add(time.Duration(*p.UTCOffsetMinutes) * time.Minute)
+	}
+	now := int(t.Weekday())*minutesPerDay + t.Hour()*60 + t.Minute()
+	for _, period := range p.Periods {
+		if period.Close == nil {
+			return true
+		}
+		open := period.Open.minuteOfWeek()
+		closing := period.Close.minuteOfWeek()
+		if closing <= open {
+			// The period wraps past Saturday midnight.
+			closing += minutesPerWeek
+		}
+		if (now >= open && now < closing) || (now+minutesPerWeek >= open && now+minutesPerWeek < closing) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t TimeOfWeek) minuteOfWeek() int {
+	return t.Day*minutesPerDay + t.Hour*60 + t.Minute
+}
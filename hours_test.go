@@ -0,0 +1,76 @@
+package goplaces
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDetailsPeriods(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{
+  "id": "place-123",
+  "utcOffsetMinutes": -300,
+  "regularOpeningHours": {
+    "weekdayDescriptions": ["Monday: 9:00 AM – 5:00 PM"],
+    "periods": [
+      {"open": {"day": 1, "hour": 9, "minute": 0}, "close": {"day": 1, "hour": 17, "minute": 0}},
+      {"open": {"day": 5, "hour": 18, "minute": 30}, "close": {"day": 6, "hour": 2, "minute": 0}}
+    ]
+  }
+}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL + "/v1"})
+	place, err := client.Details(context.Background(), "place-123")
+	if err != nil {
+		t.Fatalf("details error: %v", err)
+	}
+	if len(place.Periods) != 2 || place.Periods[1].Open != (TimeOfWeek{Day: 5, Hour: 18, Minute: 30}) ||
+		place.Periods[1].Close == nil || *place.Periods[1].Close != (TimeOfWeek{Day: 6, Hour: 2}) {
+		t.Fatalf("unexpected periods: %#v", place.Periods)
+	}
+
+	// 2026-01-05 is a Monday; the place is at UTC-5.
+	cases := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2026, 1, 5, 14, 0, 0, 0, time.UTC), true},   // Mon 09:00 local
+		{time.Date(2026, 1, 5, 13, 59, 0, 0, time.UTC), false}, // Mon 08:59 local
+		{time.Date(2026, 1, 5, 22, 0, 0, 0, time.UTC), false},  // Mon 17:00 local (close is exclusive)
+		{time.Date(2026, 1, 10, 6, 30, 0, 0, time.UTC), true},  // Sat 01:30 local, overnight from Friday
+		{time.Date(2026, 1, 10, 7, 0, 0, 0, time.UTC), false},  // Sat 02:00 local
+	}
+	for _, tc := range cases {
+		if got := place.IsOpenAt(tc.at); got != tc.want {
+			t.Fatalf("IsOpenAt(%s) = %v, want %v", tc.at, got, tc.want)
+		}
+	}
+}
+
+func TestIsOpenAtEdgeCases(t *testing.T) {
+	monday := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	if (PlaceDetails{}).IsOpenAt(monday) {
+		t.Fatalf("expected closed without periods")
+	}
+	always := PlaceDetails{Periods: []Period{{Open: TimeOfWeek{}}}}
+	if !always.IsOpenAt(monday) {
+		t.Fatalf("expected open without a close time")
+	}
+	// Saturday 22:00 to Sunday 06:00 wraps the week boundary.
+	weekend := PlaceDetails{Periods: []Period{{Open: TimeOfWeek{Day: 6, Hour: 22}, Close: &TimeOfWeek{Day: 0, Hour: 6}}}}
+	sunday := time.Date(2026, 1, 4, 3, 0, 0, 0, time.UTC)
+	if !weekend.IsOpenAt(sunday) || weekend.IsOpenAt(monday) {
+		t.Fatalf("unexpected wrap-around result")
+	}
+	// Without UTCOffsetMinutes, t's own wall clock is used.
+	local := time.Date(2026, 1, 5, 10, 0, 0, 0, time.FixedZone("X", 5*3600))
+	office := PlaceDetails{Periods: []Period{{Open: TimeOfWeek{Day: 1, Hour: 9}, Close: &TimeOfWeek{Day: 1, Hour: 17}}}}
+	if !office.IsOpenAt(local) {
+		t.Fatalf("expected open at local 10:00")
+	}
+}
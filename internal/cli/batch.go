@@ -0,0 +1,285 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/steipete/goplaces"
+)
+
+const maxBatchConcurrency = 20
+
+// batchDetail is one --ids-file lookup: details on success, the error
+// message otherwise.
+type batchDetail struct {
+	PlaceID string                 `json:"place_id"`
+	Details *goplaces.PlaceDetails `json:"details,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+// runBatch looks up every place ID in --ids-file. Individual failures are
+// recorded per ID; more than --max-errors failures stops the remaining
+// lookups.
+func (c *DetailsCmd) runBatch(app *App, request goplaces.DetailsRequest) error {
+	if c.Concurrency < 1 || c.Concurrency > maxBatchConcurrency {
+		return goplaces.ValidationError{Field: "concurrency", Message: fmt.Sprintf("must be 1-%d", maxBatchConcurrency)}
+	}
+	if c.MaxErrors < 0 {
+		return goplaces.ValidationError{Field: "max_errors", Message: "must be >= 0"}
+	}
+	ids, err := readPlaceIDs(c.IDsFile, app.in)
+	if err != nil {
+		return err
+	}
+	if app.dryRun {
+		descriptions := make([]goplaces.RequestDescription, 0, len(ids))
+		for _, id := range ids {
+			req := request
+			req.PlaceID = id
+			description, err := app.client.DescribeDetails(req)
+			if err != nil {
+				return err
+			}
+			descriptions = append(descriptions, description)
+		}
+		return writeJSON(app.out, descriptions)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results := make([]batchDetail, len(ids))
+	slots := make(chan struct{}, c.Concurrency)
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		failed  int
+		aborted bool
+	)
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			if ctx.Err() != nil {
+				return
+			}
+			req := request
+			req.PlaceID = id
+			// Each lookup gets its own --timeout; a batch can take much longer.
+			lookupCtx, done := app.deadline(ctx)
+			details, err := app.client.DetailsWithOptions(lookupCtx, req)
+			done()
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if aborted {
+					return
+				}
+				results[i] = batchDetail{PlaceID: id, Error: err.Error()}
+				failed++
+				if c.MaxErrors > 0 && failed > c.MaxErrors {
+					aborted = true
+					// Stop the remaining lookups once the threshold is crossed.
+					cancel()
+				}
+				return
+			}
+			results[i] = batchDetail{PlaceID: id, Details: &details}
+		}(i, id)
+	}
+	wg.Wait()
+
+	// Lookups skipped after an abort have no entry.
+	done := make([]batchDetail, 0, len(results))
+	for _, result := range results {
+		if result.PlaceID != "" {
+			done = append(done, result)
+		}
+	}
+
+	if app.json {
+		err = writeJSON(app.out, done)
+	} else {
+		_, err = fmt.Fprintln(app.out, renderBatchDetails(app.color, done))
+	}
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case aborted:
+		return fmt.Errorf("aborted after %d failed lookups (--max-errors %d)", failed, c.MaxErrors)
+	case failed > 0:
+		return fmt.Errorf("%d of %d lookups failed", failed, len(ids))
+	}
+	return nil
+}
+
+// batchSuggestions is one --inputs-file line: the suggestions on success,
+// the error message otherwise.
+type batchSuggestions struct {
+	Input       string                            `json:"input"`
+	Suggestions []goplaces.AutocompleteSuggestion `json:"suggestions"`
+	Error       string                            `json:"error,omitempty"`
+}
+
+// runBatch autocompletes every line of --inputs-file and prints one NDJSON
+// record per input, in file order.
+func (c *AutocompleteCmd) runBatch(app *App, request goplaces.AutocompleteRequest) error {
+	if c.Concurrency < 1 || c.Concurrency > maxBatchConcurrency {
+		return goplaces.ValidationError{Field: "concurrency", Message: fmt.Sprintf("must be 1-%d", maxBatchConcurrency)}
+	}
+	inputs, err := readAutocompleteInputs(c.InputsFile, app.in)
+	if err != nil {
+		return err
+	}
+	if app.dryRun {
+		descriptions := make([]goplaces.RequestDescription, 0, len(inputs))
+		for _, input := range inputs {
+			req := request
+			req.Input = input
+			description, err := app.client.DescribeAutocomplete(req)
+			if err != nil {
+				return err
+			}
+			descriptions = append(descriptions, description)
+		}
+		return writeJSON(app.out, descriptions)
+	}
+
+	results := make([]batchSuggestions, len(inputs))
+	slots := make(chan struct{}, c.Concurrency)
+	var wg sync.WaitGroup
+	for i, input := range inputs {
+		wg.Add(1)
+		go func(i int, input string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			req := request
+			req.Input = input
+			ctx, done := app.deadline(context.Background())
+			response, err := app.client.Autocomplete(ctx, req)
+			done()
+			if err != nil {
+				results[i] = batchSuggestions{Input: input, Error: err.Error()}
+				return
+			}
+			results[i] = batchSuggestions{Input: input, Suggestions: response.Suggestions}
+		}(i, input)
+	}
+	wg.Wait()
+
+	failed := 0
+	encoder := json.NewEncoder(app.out)
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d inputs failed", failed, len(inputs))
+	}
+	return nil
+}
+
+// readAutocompleteInputs reads one input per line from path ("-" for stdin),
+// skipping blank lines. Inputs are kept verbatim otherwise: leading spaces and
+// repeats are part of what is being evaluated.
+func readAutocompleteInputs(path string, stdin io.Reader) ([]string, error) {
+	var reader io.Reader = stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("read inputs file: %w", err)
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		reader = file
+	}
+
+	var inputs []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		input := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(input) == "" {
+			continue
+		}
+		inputs = append(inputs, input)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read inputs file: %w", err)
+	}
+	if len(inputs) == 0 {
+		return nil, goplaces.ValidationError{Field: "inputs_file", Message: "no inputs found"}
+	}
+	return inputs, nil
+}
+
+// readPlaceIDs reads one place ID per line from path ("-" for stdin),
+// skipping blanks, # comments, and repeats.
+func readPlaceIDs(path string, stdin io.Reader) ([]string, error) {
+	var reader io.Reader = stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("read ids file: %w", err)
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		reader = file
+	}
+
+	seen := make(map[string]struct{})
+	var ids []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		id := strings.TrimSpace(scanner.Text())
+		if id == "" || strings.HasPrefix(id, "#") {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read ids file: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, goplaces.ValidationError{Field: "ids_file", Message: "no place IDs found"}
+	}
+	return ids, nil
+}
+
+func renderBatchDetails(color Color, results []batchDetail) string {
+	var out bytes.Buffer
+	for i, result := range results {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		if result.Details != nil {
+			out.WriteString(renderDetails(color, *result.Details))
+			continue
+		}
+		out.WriteString(color.Bold(result.PlaceID))
+		out.WriteString("\n")
+		writeLine(&out, color, "Error", result.Error)
+	}
+	return out.String()
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/steipete/goplaces"
+)
+
+// defaultChainList is the bundled --local-only list; edit chains.txt to extend it.
+//
+//go:embed chains.txt
+var defaultChainList string
+
+// chainMatcher is a heuristic: there is no API field for "chain", so names
+// are compared against a list of well-known brands.
+type chainMatcher struct {
+	names []string
+}
+
+// localOnlyFilter returns nil unless --local-only or --chain-list is set.
+func localOnlyFilter(localOnly bool, path string) (*chainMatcher, error) {
+	if !localOnly && path == "" {
+		return nil, nil
+	}
+	matcher, err := newChainMatcher(path)
+	if err != nil {
+		return nil, err
+	}
+	return &matcher, nil
+}
+
+func newChainMatcher(path string) (chainMatcher, error) {
+	list := defaultChainList
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return chainMatcher{}, fmt.Errorf("read chain list: %w", err)
+		}
+		list = string(data)
+	}
+	return parseChainList(list), nil
+}
+
+// parseChainList reads one name per line, skipping blanks and # comments.
+func parseChainList(list string) chainMatcher {
+	var names []string
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if name := normalizeChainName(line); name != "" {
+			names = append(names, name)
+		}
+	}
+	return chainMatcher{names: names}
+}
+
+// isChain reports whether name is a listed chain or starts with one as a
+// whole word ("Starbucks Reserve" matches "Starbucks", "Subwayside" doesn't).
+func (m chainMatcher) isChain(name string) bool {
+	normalized := normalizeChainName(name)
+	for _, chain := range m.names {
+		if normalized == chain || strings.HasPrefix(normalized, chain+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// filter drops chains; a nil matcher keeps every result.
+func (m *chainMatcher) filter(results []goplaces.PlaceSummary) []goplaces.PlaceSummary {
+	if m == nil {
+		return results
+	}
+	kept := results[:0:0]
+	for _, place := range results {
+		if !m.isChain(place.Name) {
+			kept = append(kept, place)
+		}
+	}
+	return kept
+}
+
+// normalizeChainName lowercases, drops apostrophes, and turns other
+// punctuation into single spaces so "McDonald’s" and "mcdonalds" match.
+func normalizeChainName(name string) string {
+	var out strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == '\'' || r == '’':
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			out.WriteRune(r)
+		default:
+			out.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(out.String()), " ")
+}
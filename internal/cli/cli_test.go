@@ -4,10 +4,18 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"github.com/steipete/goplaces"
 )
@@ -50,6 +58,370 @@ func TestRunSearchJSON(t *testing.T) {
 	}
 }
 
+func TestRunSearchSlim(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [{
+  "id": "abc",
+  "displayName": {"text": "Cafe"},
+  "formattedAddress": "1 Main St",
+  "location": {"latitude": 1.5, "longitude": 2.5},
+  "rating": 4.4,
+  "userRatingCount": 12,
+  "types": ["cafe"]
+}]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"search",
+		"coffee",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--slim",
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var places []map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &places); err != nil {
+		t.Fatalf("decode output: %v (%s)", err, stdout.String())
+	}
+	if len(places) != 1 {
+		t.Fatalf("expected 1 place, got %d", len(places))
+	}
+	want := map[string]any{"place_id": "abc", "name": "Cafe", "lat": 1.5, "lng": 2.5, "rating": 4.4}
+	if len(places[0]) != len(want) {
+		t.Fatalf("unexpected slim keys: %#v", places[0])
+	}
+	for key, value := range want {
+		if places[0][key] != value {
+			t.Fatalf("unexpected %s: %#v", key, places[0][key])
+		}
+	}
+}
+
+func TestRunGeoLocation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [{"id": "abc", "displayName": {"text": "Cafe"}, "location": {"latitude": 40.5, "longitude": -73.25}}]}`))
+	}))
+	defer server.Close()
+
+	for _, args := range [][]string{
+		{"search", "coffee"},
+		{"nearby", "--lat", "40.5", "--lng=-73.25", "--radius-m", "500"},
+		{"resolve", "Cafe"},
+	} {
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+
+		exitCode := Run(append(args, "--api-key", "test-key", "--base-url", server.URL, "--geo-location"), &stdout, &stderr)
+		if exitCode != 0 {
+			t.Fatalf("%s: expected exit code 0, got %d (stderr=%s)", args[0], exitCode, stderr.String())
+		}
+		var places []struct {
+			PlaceID  string         `json:"place_id"`
+			Name     string         `json:"name"`
+			Location map[string]any `json:"location"`
+		}
+		if err := json.Unmarshal(stdout.Bytes(), &places); err != nil {
+			t.Fatalf("%s: decode output: %v (%s)", args[0], err, stdout.String())
+		}
+		if len(places) != 1 || places[0].PlaceID != "abc" || places[0].Name != "Cafe" {
+			t.Fatalf("%s: unexpected places: %#v", args[0], places)
+		}
+		location := places[0].Location
+		if len(location) != 2 || location["type"] != "Point" {
+			t.Fatalf("%s: unexpected location: %#v", args[0], location)
+		}
+		coordinates, ok := location["coordinates"].([]any)
+		if !ok || len(coordinates) != 2 || coordinates[0] != -73.25 || coordinates[1] != 40.5 {
+			t.Fatalf("%s: expected [lng, lat] coordinates, got %#v", args[0], location["coordinates"])
+		}
+	}
+
+	var stderr bytes.Buffer
+	exitCode := Run([]string{"search", "coffee", "--api-key", "test-key", "--geo-location", "--format", "csv"}, &bytes.Buffer{}, &stderr)
+	if exitCode != 2 || !strings.Contains(stderr.String(), "--geo-location or --format csv") {
+		t.Fatalf("expected format conflict, got %d (%s)", exitCode, stderr.String())
+	}
+}
+
+func TestRunFirst(t *testing.T) {
+	empty := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if empty {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"places": [
+  {"id": "low", "displayName": {"text": "Low"}, "rating": 3.1},
+  {"id": "high", "displayName": {"text": "High"}, "rating": 4.8}
+]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"search", "coffee",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--sort", "rating",
+		"--first", "--json",
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var place map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &place); err != nil {
+		t.Fatalf("expected a single JSON object: %v (%s)", err, stdout.String())
+	}
+	if place["place_id"] != "high" {
+		t.Fatalf("expected top-rated place, got %#v", place)
+	}
+
+	stdout.Reset()
+	exitCode = Run([]string{"resolve", "Cafe", "--api-key", "test-key", "--base-url", server.URL, "--first", "--format", "csv", "--no-header"}, &stdout, &stderr)
+	if exitCode != 0 || strings.Count(stdout.String(), "\n") != 1 || !strings.HasPrefix(stdout.String(), "low,") {
+		t.Fatalf("expected one csv row, got %d: %q", exitCode, stdout.String())
+	}
+
+	empty = true
+	stdout.Reset()
+	stderr.Reset()
+	exitCode = Run([]string{"nearby", "--lat", "1", "--lng", "2", "--radius-m", "100", "--api-key", "test-key", "--base-url", server.URL, "--first"}, &stdout, &stderr)
+	if exitCode != 1 || stdout.Len() != 0 || !strings.Contains(stderr.String(), "no place found") {
+		t.Fatalf("expected failure without results, got %d (stdout=%q stderr=%q)", exitCode, stdout.String(), stderr.String())
+	}
+}
+
+func TestRunIDsOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [
+  {"id": "p1", "displayName": {"text": "One"}, "formattedAddress": "1 Main St"},
+  {"id": "p2", "displayName": {"text": "Two"}}
+], "nextPageToken": "next"}`))
+	}))
+	defer server.Close()
+
+	for _, args := range [][]string{
+		{"search", "coffee", "--language", "ja"},
+		{"nearby", "--lat", "1", "--lng", "2", "--radius-m", "100"},
+		{"resolve", "Main St"},
+	} {
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		exitCode := Run(append(args, "--api-key", "test-key", "--base-url", server.URL, "--ids-only"), &stdout, &stderr)
+		if exitCode != 0 {
+			t.Fatalf("%s: expected exit code 0, got %d (stderr=%s)", args[0], exitCode, stderr.String())
+		}
+		if stdout.String() != "p1\np2\n" {
+			t.Fatalf("%s: expected only ids on stdout, got %q", args[0], stdout.String())
+		}
+	}
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{"search", "coffee", "--api-key", "test-key", "--base-url", server.URL, "--ids-only", "--json"}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var ids []string
+	if err := json.Unmarshal(stdout.Bytes(), &ids); err != nil || len(ids) != 2 || ids[0] != "p1" {
+		t.Fatalf("expected JSON array of ids, got %q (%v)", stdout.String(), err)
+	}
+}
+
+func TestRunSearchFieldsMinimal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Goog-FieldMask"); got != "places.id,nextPageToken" {
+			t.Fatalf("unexpected field mask: %s", got)
+		}
+		_, _ = w.Write([]byte(`{"places": [{"id": "p1"}]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{"search", "coffee", "--api-key", "test-key", "--base-url", server.URL, "--fields-minimal", "--ids-only"}, &stdout, &stderr)
+	if exitCode != 0 || stdout.String() != "p1\n" {
+		t.Fatalf("expected exit code 0 and one id, got %d %q (stderr=%s)", exitCode, stdout.String(), stderr.String())
+	}
+
+	exitCode = Run([]string{"search", "coffee", "--api-key", "test-key", "--fields-minimal", "--fields", "rating"}, &bytes.Buffer{}, &stderr)
+	if exitCode != 2 {
+		t.Fatalf("expected validation error for --fields-minimal with --fields, got %d", exitCode)
+	}
+}
+
+func TestRunDetailsRaw(t *testing.T) {
+	body := `{"id": "abc", "editorialSummary": {"text": "Cozy"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Goog-FieldMask"); got != "id,editorialSummary" {
+			t.Fatalf("unexpected field mask: %s", got)
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{"details", "abc", "--raw", "--fields", "editorialSummary", "--api-key", "test-key", "--base-url", server.URL}, &stdout, &stderr)
+	if exitCode != 0 || stdout.String() != body+"\n" {
+		t.Fatalf("expected raw body, got %d %q (stderr=%s)", exitCode, stdout.String(), stderr.String())
+	}
+
+	for _, args := range [][]string{
+		{"search", "coffee", "--raw", "--first"},
+		{"search", "coffee", "--raw", "--all"},
+		{"nearby", "--lat", "1", "--lng", "2", "--radius-m", "100", "--raw", "--grid"},
+	} {
+		exitCode = Run(append(args, "--api-key", "test-key"), &bytes.Buffer{}, &bytes.Buffer{})
+		if exitCode != 2 {
+			t.Fatalf("%v: expected validation error, got %d", args, exitCode)
+		}
+	}
+}
+
+func TestRunSearchWarnsOnSwappedLocale(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"search", "coffee",
+		"--region", "en",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "warning: --region en looks like a language code; did you mean --language en --region US?") {
+		t.Fatalf("expected locale warning, got: %s", stderr.String())
+	}
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	var stderr bytes.Buffer
+	app := &App{err: &stderr}
+
+	language, region := normalizeLocale(app, " en ", " JP ")
+	if language != "en" || region != "JP" || stderr.Len() != 0 {
+		t.Fatalf("unexpected normalize result: %q %q (stderr=%s)", language, region, stderr.String())
+	}
+	normalizeLocale(app, "JP", "")
+	if !strings.Contains(stderr.String(), "--language JP looks like a region code; did you mean --language ja --region JP?") {
+		t.Fatalf("expected language warning, got: %s", stderr.String())
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	for locale, want := range map[string][2]string{"en-US": {"en", "US"}, "pt-BR": {"pt", "BR"}, "pt_br": {"pt", "BR"}} {
+		language, region, err := parseLocale(locale)
+		if err != nil || language != want[0] || region != want[1] {
+			t.Fatalf("parseLocale(%q) = %q, %q, %v", locale, language, region, err)
+		}
+	}
+	var validation goplaces.ValidationError
+	if _, _, err := parseLocale("english"); !errors.As(err, &validation) || validation.Field != "locale" {
+		t.Fatalf("expected locale validation error, got %v", err)
+	}
+}
+
+func TestRunLocale(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		args             []string
+		language, region string
+	}{
+		{[]string{"--locale", "pt-BR"}, "pt", "BR"},
+		{[]string{"--locale", "en-US", "--region", "CA"}, "en", "CA"},
+	} {
+		var stderr bytes.Buffer
+		args := append([]string{"search", "cafe", "--json", "--api-key", "x", "--base-url", server.URL}, tc.args...)
+		if exitCode := Run(args, &bytes.Buffer{}, &stderr); exitCode != 0 {
+			t.Fatalf("%v: expected exit code 0, got %d (stderr=%s)", tc.args, exitCode, stderr.String())
+		}
+		if gotBody["languageCode"] != tc.language || gotBody["regionCode"] != tc.region {
+			t.Fatalf("%v: unexpected locale in body: %#v", tc.args, gotBody)
+		}
+	}
+
+	var stderr bytes.Buffer
+	exitCode := Run([]string{"search", "cafe", "--locale", "english", "--api-key", "x", "--base-url", server.URL}, &bytes.Buffer{}, &stderr)
+	if exitCode != 2 || !strings.Contains(stderr.String(), "locale") {
+		t.Fatalf("expected locale validation error, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+}
+
+func TestNoteNameLanguage(t *testing.T) {
+	var stderr bytes.Buffer
+	app := &App{err: &stderr}
+
+	noteNameLanguage(app, "en-GB", "en", "EN-US", "")
+	noteNameLanguage(app, "", "fr")
+	if stderr.Len() != 0 {
+		t.Fatalf("unexpected note: %s", stderr.String())
+	}
+	noteNameLanguage(app, "ja", "en")
+	if stderr.String() != "note: place name is in en, not ja\n" {
+		t.Fatalf("unexpected single note: %q", stderr.String())
+	}
+	stderr.Reset()
+	noteNameLanguage(app, "ja", "ja", "en", "en")
+	if stderr.String() != "note: 2 of 3 place names are not in ja\n" {
+		t.Fatalf("unexpected summary note: %q", stderr.String())
+	}
+	stderr.Reset()
+	app.quiet = true
+	noteNameLanguage(app, "ja", "en")
+	if stderr.Len() != 0 {
+		t.Fatalf("expected --quiet to drop the note: %q", stderr.String())
+	}
+}
+
+func TestRunDetailsNameLanguageNote(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "place-1", "displayName": {"text": "Cafe", "languageCode": "en"}}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"details", "place-1",
+		"--language", "ja",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--no-color",
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "note: place name is in en, not ja") {
+		t.Fatalf("expected name language note, got: %s", stderr.String())
+	}
+}
+
 func TestRunSearchHuman(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		_, _ = w.Write([]byte(`{"places": [{"id": "abc", "displayName": {"text": "Cafe"}}]}`))
@@ -126,6 +498,49 @@ func TestRunSearchWithFilters(t *testing.T) {
 	}
 }
 
+func TestRunSearchEchoRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [{"id": "abc"}]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"search",
+		"coffee",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--json",
+		"--echo-request",
+		"--language", "en",
+		"--lat", "1",
+		"--lng", "2",
+		"--radius-m", "300",
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var payload struct {
+		Request map[string]any   `json:"request"`
+		Results []map[string]any `json:"results"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output: %v (%s)", err, stdout.String())
+	}
+	if payload.Request["query"] != "coffee" || payload.Request["language"] != "en" {
+		t.Fatalf("unexpected request echo: %#v", payload.Request)
+	}
+	if payload.Request["location_bias"] == nil {
+		t.Fatalf("expected location bias in echo: %#v", payload.Request)
+	}
+	if len(payload.Results) != 1 || payload.Results[0]["place_id"] != "abc" {
+		t.Fatalf("unexpected results: %#v", payload.Results)
+	}
+}
+
 func TestRunAutocompleteJSON(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/places:autocomplete" {
@@ -182,6 +597,33 @@ func TestRunAutocompleteHuman(t *testing.T) {
 	}
 }
 
+func TestRunAutocompleteOrigin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		origin, _ := body["origin"].(map[string]any)
+		if origin["latitude"] != 47.6 || origin["longitude"] != -122.3 {
+			t.Fatalf("unexpected origin: %#v", body["origin"])
+		}
+		_, _ = w.Write([]byte(`{"suggestions": [{"placePrediction": {"placeId": "abc", "text": {"text": "Cafe"}, "distanceMeters": 350}}]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{"autocomplete", "coffee", "--origin=47.6,-122.3", "--no-color", "--api-key", "test-key", "--base-url", server.URL}, &stdout, &stderr)
+	if exitCode != 0 || !strings.Contains(stdout.String(), "Distance: 350m") {
+		t.Fatalf("expected distance line, got %d %q (stderr=%s)", exitCode, stdout.String(), stderr.String())
+	}
+
+	for _, origin := range []string{"--origin=47.6", "--origin=91,0", "--origin=x,1"} {
+		exitCode = Run([]string{"autocomplete", "coffee", origin, "--api-key", "test-key"}, &bytes.Buffer{}, &bytes.Buffer{})
+		if exitCode != 2 {
+			t.Fatalf("%s: expected validation error exit code 2, got %d", origin, exitCode)
+		}
+	}
+}
+
 func TestRunNearbyJSON(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != placesNearbyPath {
@@ -277,6 +719,51 @@ func TestRunRouteJSON(t *testing.T) {
 	}
 }
 
+func TestRunRouteSharesDeadline(t *testing.T) {
+	var searches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Each request alone finishes well inside --timeout; together they do not.
+		select {
+		case <-time.After(60 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		switch r.URL.Path {
+		case routesComputePath:
+			_, _ = w.Write([]byte("{\"routes\":[{\"polyline\":{\"encodedPolyline\":\"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+		case placesSearchPath:
+			searches.Add(1)
+			_, _ = w.Write([]byte(`{"places":[{"id":"abc","displayName":{"text":"Cafe"}}]}`))
+		}
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	started := time.Now()
+	exitCode := Run([]string{
+		"route", "coffee",
+		"--from", "A",
+		"--to", "B",
+		"--concurrency", "1",
+		"--timeout", "150ms",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--routes-base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+
+	if exitCode != 1 || !strings.Contains(stderr.String(), "deadline exceeded") {
+		t.Fatalf("expected deadline exceeded, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("expected the shared deadline to stop the route, took %v", elapsed)
+	}
+	if searches.Load() >= 3 {
+		t.Fatalf("expected waypoint searches to be cut short, got %d", searches.Load())
+	}
+}
+
 func TestRunRouteHuman(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -332,6 +819,84 @@ func TestRunRouteValidationError(t *testing.T) {
 	}
 }
 
+func TestRunRouteTransitFlags(t *testing.T) {
+	var transit map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		transit = body
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	exitCode := Run([]string{
+		"route", "coffee", "--from", "A", "--to", "B", "--mode", "TRANSIT",
+		"--depart-at", "2099-01-02T03:04:05+01:00", "--transit-mode", "bus", "--transit-pref", "less-walking",
+		"--api-key", "test-key", "--routes-base-url", server.URL,
+	}, &bytes.Buffer{}, &bytes.Buffer{})
+	if exitCode != 1 {
+		t.Fatalf("expected the stub's API error, got %d", exitCode)
+	}
+	prefs, _ := transit["transitPreferences"].(map[string]any)
+	if transit["departureTime"] != "2099-01-02T02:04:05Z" || prefs["routingPreference"] != "LESS_WALKING" {
+		t.Fatalf("unexpected transit body: %#v", transit)
+	}
+
+	// "now" must not send a timestamp that is stale on arrival: driving
+	// routes get only the traffic-aware preference.
+	exitCode = Run([]string{
+		"route", "coffee", "--from", "A", "--to", "B", "--depart-at", "now",
+		"--api-key", "test-key", "--routes-base-url", server.URL,
+	}, &bytes.Buffer{}, &bytes.Buffer{})
+	if exitCode != 1 {
+		t.Fatalf("expected the stub's API error, got %d", exitCode)
+	}
+	if _, ok := transit["departureTime"]; ok || transit["routingPreference"] != "TRAFFIC_AWARE" || transit["travelMode"] != "DRIVE" {
+		t.Fatalf("unexpected drive body for now: %#v", transit)
+	}
+
+	exitCode = Run([]string{
+		"route", "coffee", "--from", "A", "--to", "B", "--depart-at", "now", "--routing-preference", "traffic-aware-optimal",
+		"--api-key", "test-key", "--routes-base-url", server.URL,
+	}, &bytes.Buffer{}, &bytes.Buffer{})
+	if exitCode != 1 || transit["routingPreference"] != "TRAFFIC_AWARE_OPTIMAL" {
+		t.Fatalf("expected TRAFFIC_AWARE_OPTIMAL, got %d %#v", exitCode, transit)
+	}
+
+	for _, args := range [][]string{
+		{"--mode", "WALK", "--depart-at", "now"},
+		{"--routing-preference", "traffic-aware"},
+		{"--mode", "TRANSIT", "--depart-at", "tomorrow"},
+		{"--mode", "TRANSIT", "--depart-at", "2001-01-01T00:00:00Z"},
+		{"--transit-mode", "BUS"},
+	} {
+		exitCode = Run(append([]string{"route", "coffee", "--from", "A", "--to", "B", "--api-key", "test-key"}, args...), &bytes.Buffer{}, &bytes.Buffer{})
+		if exitCode != 2 {
+			t.Fatalf("%v: expected validation error exit code 2, got %d", args, exitCode)
+		}
+	}
+}
+
+func TestRunRouteVia(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	exitCode := Run([]string{
+		"route", "coffee", "--from", "Seattle", "--to", "Portland", "--via", "Tacoma", "--via", "Olympia",
+		"--api-key", "test-key", "--routes-base-url", server.URL,
+	}, &bytes.Buffer{}, &bytes.Buffer{})
+	if exitCode != 1 {
+		t.Fatalf("expected the stub's API error, got %d", exitCode)
+	}
+	if intermediates, _ := gotBody["intermediates"].([]any); len(intermediates) != 2 {
+		t.Fatalf("unexpected intermediates: %#v", gotBody["intermediates"])
+	}
+}
+
 func TestRunRouteMissingFrom(t *testing.T) {
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
@@ -376,10 +941,173 @@ func TestRunDetailsJSON(t *testing.T) {
 	}
 }
 
-func TestRunDetailsWithReviews(t *testing.T) {
+func TestRunDetailsShortAddress(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if !strings.Contains(r.Header.Get("X-Goog-FieldMask"), "reviews") {
-			t.Fatalf("expected reviews in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+		if !strings.HasSuffix(r.Header.Get("X-Goog-FieldMask"), ",shortFormattedAddress") {
+			t.Fatalf("expected shortFormattedAddress in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+		}
+		_, _ = w.Write([]byte(`{"id": "place-1", "formattedAddress": "1 Main St, Springfield, IL 62701, USA", "shortFormattedAddress": "1 Main St, Springfield"}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"details", "place-1",
+		"--short-address",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--no-color",
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "ID: place-1\nShort address: 1 Main St, Springfield\n") {
+		t.Fatalf("unexpected stdout: %s", stdout.String())
+	}
+}
+
+func TestRunDetailsIDsFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/places/")
+		if strings.HasPrefix(id, "bad") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("not found"))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"id": %q, "displayName": {"text": "Place %s"}}`, id, id)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "ids.txt")
+	if err := os.WriteFile(path, []byte("a\n\n# comment\nbad-1\nb\na\n"), 0o600); err != nil {
+		t.Fatalf("write ids: %v", err)
+	}
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"details",
+		"--ids-file", path,
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+
+	if exitCode != 1 || !strings.Contains(stderr.String(), "1 of 3 lookups failed") {
+		t.Fatalf("expected partial failure, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var results []batchDetail
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatalf("decode output: %v (%s)", err, stdout.String())
+	}
+	if len(results) != 3 || results[0].PlaceID != "a" || results[1].PlaceID != "bad-1" || results[2].PlaceID != "b" {
+		t.Fatalf("unexpected results: %#v", results)
+	}
+	if results[0].Details == nil || results[0].Details.Name != "Place a" || results[0].Error != "" {
+		t.Fatalf("unexpected success entry: %#v", results[0])
+	}
+	if results[1].Details != nil || !strings.Contains(results[1].Error, "404") {
+		t.Fatalf("unexpected error entry: %#v", results[1])
+	}
+}
+
+func TestRunAutocompleteInputsFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = fmt.Fprintf(w, `{"suggestions": [{"queryPrediction": {"text": {"text": "%s cafe"}}}]}`, body["input"])
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "inputs.txt")
+	if err := os.WriteFile(path, []byte("pizza\n\nsushi\n"), 0o600); err != nil {
+		t.Fatalf("write inputs: %v", err)
+	}
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"autocomplete",
+		"--inputs-file", path,
+		"--concurrency", "2",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 NDJSON records, got %q", stdout.String())
+	}
+	for i, want := range []string{"pizza", "sushi"} {
+		var record batchSuggestions
+		if err := json.Unmarshal([]byte(lines[i]), &record); err != nil {
+			t.Fatalf("decode record %d: %v (%s)", i, err, lines[i])
+		}
+		if record.Input != want || len(record.Suggestions) != 1 || record.Suggestions[0].Text != want+" cafe" {
+			t.Fatalf("unexpected record %d: %#v", i, record)
+		}
+	}
+}
+
+func TestRunDetailsIDsFileMaxErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "ids.txt")
+	if err := os.WriteFile(path, []byte("a\nb\nc\nd\ne\nf\n"), 0o600); err != nil {
+		t.Fatalf("write ids: %v", err)
+	}
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"details",
+		"--ids-file", path,
+		"--concurrency", "1",
+		"--max-errors", "1",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--no-color",
+	}, &stdout, &stderr)
+
+	if exitCode != 1 || !strings.Contains(stderr.String(), "aborted after 2 failed lookups (--max-errors 1)") {
+		t.Fatalf("expected abort, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected lookups to stop after the threshold, got %d calls", calls.Load())
+	}
+	if !strings.HasPrefix(stdout.String(), "a\nError: ") || strings.Count(stdout.String(), "Error:") != 2 {
+		t.Fatalf("unexpected stdout: %q", stdout.String())
+	}
+}
+
+func TestReadPlaceIDsStdin(t *testing.T) {
+	ids, err := readPlaceIDs("-", strings.NewReader(" p1 \r\np2\n"))
+	if err != nil || len(ids) != 2 || ids[0] != "p1" || ids[1] != "p2" {
+		t.Fatalf("unexpected ids: %v (%v)", ids, err)
+	}
+	var validation goplaces.ValidationError
+	if _, err := readPlaceIDs("-", strings.NewReader("# none\n")); !errors.As(err, &validation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestRunDetailsWithReviews(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("X-Goog-FieldMask"), "reviews") {
+			t.Fatalf("expected reviews in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
 		}
 		_, _ = w.Write([]byte(`{"id": "place-1", "reviews": [{"name": "reviews/1"}]}`))
 	}))
@@ -513,6 +1241,78 @@ func TestRunPhotoHuman(t *testing.T) {
 	}
 }
 
+func TestRunPhotoOutputAddsExtension(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/image" {
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte("png-bytes"))
+			return
+		}
+		_, _ = w.Write([]byte(`{"photoUri": "` + server.URL + `/image"}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"photo",
+		"places/place-1/photos/photo-1",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--output", filepath.Join(dir, "cover"),
+		"--no-color",
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", exitCode, stderr.String())
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "cover.png"))
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if string(data) != "png-bytes" {
+		t.Fatalf("unexpected file contents: %q", data)
+	}
+	if !strings.Contains(stdout.String(), "cover.png (9 bytes, image/png)") {
+		t.Fatalf("unexpected stdout: %s", stdout.String())
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("expected temp file cleanup, got %d entries", len(entries))
+	}
+}
+
+func TestRunReverseHuman(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/places:searchNearby" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"places": [{"id": "p1", "displayName": {"text": "Corner Cafe"}, "formattedAddress": "1 Main St, Seattle", "location": {"latitude": 47.6, "longitude": -122.33}}]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"reverse",
+		"--lat", "47.6", "--lng=-122.33",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--no-color",
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if !strings.HasPrefix(stdout.String(), "1 Main St, Seattle\nPlace: Corner Cafe\nID: p1\n") {
+		t.Fatalf("unexpected stdout: %q", stdout.String())
+	}
+}
+
 func TestRunResolveHuman(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != placesSearchPath {
@@ -582,6 +1382,19 @@ func TestRunVersion(t *testing.T) {
 	}
 }
 
+func TestRunRejectsOutOfRangeRetries(t *testing.T) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{"--retries", "40", "search", "coffee"}, &stdout, &stderr)
+	if exitCode != 2 {
+		t.Fatalf("expected exit code 2, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "retries") {
+		t.Fatalf("expected retries error, got: %s", stderr.String())
+	}
+}
+
 func TestRunMissingCommand(t *testing.T) {
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
@@ -612,6 +1425,68 @@ func TestRunLocationBiasError(t *testing.T) {
 	}
 }
 
+func TestLocationErrorNamesMissingFields(t *testing.T) {
+	lat := 1.0
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{locationError("location_bias", &lat, nil, nil, true), "lng and radius_m required when lat is set"},
+		{locationError("location_bias", &lat, nil, nil, false), "lng required when lat is set"},
+		{locationError("location_restriction", nil, nil, nil, true), "lat, lng, and radius_m required"},
+		{locationError("location_bias", nil, &lat, &lat, true), "lat required when lng and radius_m are set"},
+	}
+	for _, tt := range tests {
+		var validationErr goplaces.ValidationError
+		if !errors.As(tt.err, &validationErr) || validationErr.Message != tt.want {
+			t.Fatalf("expected %q, got %v", tt.want, tt.err)
+		}
+	}
+}
+
+func TestRunLocationBiasErrorMessage(t *testing.T) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{"search", "coffee", "--lat", "1", "--api-key", "x"}, &stdout, &stderr)
+	if exitCode != 2 {
+		t.Fatalf("expected validation error exit code 2, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "lng required when lat is set") {
+		t.Fatalf("expected missing field in message, got %q", stderr.String())
+	}
+}
+
+func TestRunLocationBiasDefaultRadius(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"search", "coffee",
+		"--lat", "1", "--lng", "2",
+		"--api-key", "x",
+		"--base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	bias, _ := gotBody["locationBias"].(map[string]any)
+	circle, _ := bias["circle"].(map[string]any)
+	if circle["radius"] != float64(defaultSearchBiasRadiusM) {
+		t.Fatalf("expected default radius, got %#v", gotBody["locationBias"])
+	}
+}
+
 func TestRunNearbyLocationRestrictionError(t *testing.T) {
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
@@ -741,3 +1616,1074 @@ func TestHandleError(t *testing.T) {
 		t.Fatalf("expected generic exit 1")
 	}
 }
+
+func TestRunDoctorOK(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [{"id": "abc"}]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"doctor",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--routes-base-url", server.URL,
+		"--no-color",
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stdout=%s stderr=%s)", exitCode, stdout.String(), stderr.String())
+	}
+	for _, want := range []string{"[ok]   API key", "[ok]   Places API", "[ok]   Routes API", "[ok]   Clock"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Fatalf("missing %q in output: %s", want, stdout.String())
+		}
+	}
+}
+
+func TestRunDoctorForbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"doctor",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--routes-base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+
+	if exitCode != 1 {
+		t.Fatalf("expected exit code 1, got %d", exitCode)
+	}
+	if !strings.Contains(stdout.String(), "Enable Places API (New)") {
+		t.Fatalf("expected remediation, got: %s", stdout.String())
+	}
+}
+
+func TestRunDoctorMissingKey(t *testing.T) {
+	t.Setenv("GOOGLE_PLACES_API_KEY", "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{"doctor", "--routes-base-url", server.URL}, &stdout, &stderr)
+	if exitCode != 1 {
+		t.Fatalf("expected exit code 1, got %d", exitCode)
+	}
+	if !strings.Contains(stdout.String(), "GOOGLE_PLACES_API_KEY") {
+		t.Fatalf("expected api key remediation, got: %s", stdout.String())
+	}
+}
+
+func TestClockSkewCheck(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	if check := clockSkewCheck(time.Time{}, now); check != nil {
+		t.Fatalf("expected no check without server time")
+	}
+	if check := clockSkewCheck(now.Add(-10*time.Minute), now); check == nil || check.Status != checkWarn {
+		t.Fatalf("expected skew warning, got %#v", check)
+	}
+}
+
+func TestRunSearchHidesClosedByDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("X-Goog-FieldMask"), "places.businessStatus") {
+			t.Fatalf("expected businessStatus in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+		}
+		_, _ = w.Write([]byte(`{"places": [
+  {"id": "open", "businessStatus": "OPERATIONAL"},
+  {"id": "gone", "businessStatus": "CLOSED_PERMANENTLY"},
+  {"id": "paused", "businessStatus": "CLOSED_TEMPORARILY"},
+  {"id": "unknown"}
+]}`))
+	}))
+	defer server.Close()
+
+	run := func(extra ...string) []goplaces.PlaceSummary {
+		t.Helper()
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		args := append([]string{
+			"search",
+			"coffee",
+			"--api-key", "test-key",
+			"--base-url", server.URL,
+			"--json",
+		}, extra...)
+		if exitCode := Run(args, &stdout, &stderr); exitCode != 0 {
+			t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+		}
+		var results []goplaces.PlaceSummary
+		if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+			t.Fatalf("decode output: %v", err)
+		}
+		return results
+	}
+
+	if results := run(); len(results) != 2 || results[0].PlaceID != "open" || results[1].PlaceID != "unknown" {
+		t.Fatalf("expected closed places hidden, got %#v", results)
+	}
+	if results := run("--include-closed"); len(results) != 4 {
+		t.Fatalf("expected all places with --include-closed, got %#v", results)
+	}
+}
+
+func TestRunNearbyIncludeClosed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("X-Goog-FieldMask"), "places.businessStatus") {
+			t.Fatalf("expected businessStatus in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+		}
+		_, _ = w.Write([]byte(`{"places": [{"id": "gone", "displayName": {"text": "Gone"}, "businessStatus": "CLOSED_PERMANENTLY"}]}`))
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		args []string
+		want bool
+	}{
+		{want: false},
+		{args: []string{"--include-closed"}, want: true},
+	} {
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		args := append([]string{
+			"nearby",
+			"--lat", "1",
+			"--lng", "2",
+			"--radius-m", "3",
+			"--api-key", "test-key",
+			"--base-url", server.URL,
+		}, tc.args...)
+		if exitCode := Run(args, &stdout, &stderr); exitCode != 0 {
+			t.Fatalf("expected exit code 0, got %d", exitCode)
+		}
+		if got := strings.Contains(stdout.String(), "Gone"); got != tc.want {
+			t.Fatalf("args %v: expected closed place shown=%v, got: %s", tc.args, tc.want, stdout.String())
+		}
+	}
+}
+
+func TestRunNearbyLimitAboveCapExplains(t *testing.T) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"nearby",
+		"--lat", "1",
+		"--lng", "2",
+		"--radius-m", "3",
+		"--limit", "50",
+		"--api-key", "test-key",
+	}, &stdout, &stderr)
+
+	if exitCode != 2 {
+		t.Fatalf("expected validation exit code 2, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "at most 20 results") || !strings.Contains(stderr.String(), "text search") {
+		t.Fatalf("expected cap explanation, got: %s", stderr.String())
+	}
+}
+
+func TestRunNearbyGrid(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != placesNearbyPath {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		n := calls.Add(1)
+		_, _ = w.Write([]byte(`{"places": [{"id": "shared"}, {"id": "tile-` + strconv.Itoa(int(n)) + `"}]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"nearby",
+		"--lat", "1",
+		"--lng", "2",
+		"--radius-m", "1000",
+		"--grid",
+		"--tile-m", "500",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var results []goplaces.PlaceSummary
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if calls.Load() < 2 || len(results) != int(calls.Load())+1 {
+		t.Fatalf("expected deduped grid results, calls=%d results=%d", calls.Load(), len(results))
+	}
+}
+
+func TestFlattenRoute(t *testing.T) {
+	response := goplaces.RouteResponse{
+		Waypoints: []goplaces.RouteWaypoint{
+			{Results: []goplaces.PlaceSummary{{PlaceID: "a"}, {PlaceID: "b"}}},
+			{Results: []goplaces.PlaceSummary{{PlaceID: "b"}, {PlaceID: "c"}}},
+			{Results: []goplaces.PlaceSummary{{PlaceID: "a"}}},
+		},
+	}
+	places := flattenRoute(response)
+	if len(places) != 3 {
+		t.Fatalf("expected 3 places, got %#v", places)
+	}
+	want := map[string][]int{"a": {0, 2}, "b": {0, 1}, "c": {1}}
+	for _, place := range places {
+		got := place.WaypointIndexes
+		if len(got) != len(want[place.PlaceID]) {
+			t.Fatalf("unexpected indexes for %s: %v", place.PlaceID, got)
+		}
+		for i := range got {
+			if got[i] != want[place.PlaceID][i] {
+				t.Fatalf("unexpected indexes for %s: %v", place.PlaceID, got)
+			}
+		}
+	}
+}
+
+func TestRunRouteFlattenJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case routesComputePath:
+			_, _ = w.Write([]byte("{\"routes\":[{\"polyline\":{\"encodedPolyline\":\"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+		case placesSearchPath:
+			_, _ = w.Write([]byte(`{"places":[{"id":"abc","displayName":{"text":"Cafe"}}]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"route",
+		"coffee",
+		"--from", "A",
+		"--to", "B",
+		"--max-waypoints", "2",
+		"--flatten",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--routes-base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var places []struct {
+		PlaceID         string `json:"place_id"`
+		Name            string `json:"name"`
+		WaypointIndexes []int  `json:"waypoint_indexes"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &places); err != nil {
+		t.Fatalf("decode output: %v (%s)", err, stdout.String())
+	}
+	if len(places) != 1 || places[0].Name != "Cafe" || len(places[0].WaypointIndexes) != 2 {
+		t.Fatalf("unexpected flattened output: %#v", places)
+	}
+}
+
+func TestRunRouteFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case routesComputePath:
+			_, _ = w.Write([]byte("{\"routes\":[{\"polyline\":{\"encodedPolyline\":\"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+		case placesSearchPath:
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body["minRating"] != 4.5 || body["openNow"] != true || body["includedType"] != "cafe" {
+				t.Fatalf("unexpected filters: %#v", body)
+			}
+			_, _ = w.Write([]byte(`{"places":[]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"route",
+		"coffee",
+		"--from", "A",
+		"--to", "B",
+		"--min-rating", "4.5",
+		"--open-now",
+		"--type", "cafe",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--routes-base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+}
+
+func TestRunTiming(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"search", "coffee",
+		"--timing",
+		"--api-key", "x",
+		"--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	line := strings.TrimSpace(stderr.String())
+	if !strings.HasPrefix(line, "timing: POST /places:searchText ") || !strings.HasSuffix(line, "ms") {
+		t.Fatalf("unexpected timing output: %q", stderr.String())
+	}
+	ms, err := strconv.Atoi(strings.TrimSuffix(strings.Fields(line)[3], "ms"))
+	if err != nil || ms < 20 {
+		t.Fatalf("expected latency >= 20ms, got %q", line)
+	}
+	if strings.Contains(stdout.String(), "timing") {
+		t.Fatalf("timing should not go to stdout")
+	}
+}
+
+func TestRequestTimerTotal(t *testing.T) {
+	var out bytes.Buffer
+	timer := &requestTimer{out: &out, json: true}
+	timer.record(goplaces.RequestInfo{Method: "POST", URL: "https://example.com/v1/places:searchText", Duration: 5 * time.Millisecond})
+	timer.record(goplaces.RequestInfo{Method: "POST", URL: "https://example.com/v1/places:searchText", Duration: 7 * time.Millisecond})
+	timer.finish()
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 || lines[2] != `{"requests":2,"total_ms":12}` {
+		t.Fatalf("unexpected timing output: %q", out.String())
+	}
+	if !strings.Contains(lines[0], `"path":"/v1/places:searchText"`) {
+		t.Fatalf("unexpected timing line: %s", lines[0])
+	}
+}
+
+func TestRunSearchSortRating(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [
+			{"id": "few", "rating": 4.8, "userRatingCount": 3},
+			{"id": "low", "rating": 4.1, "userRatingCount": 900},
+			{"id": "many", "rating": 4.8, "userRatingCount": 2000}
+		]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"search", "coffee",
+		"--sort", "rating",
+		"--api-key", "x",
+		"--base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var results []goplaces.PlaceSummary
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(results) != 3 || results[0].PlaceID != "many" || results[1].PlaceID != "few" || results[2].PlaceID != "low" {
+		t.Fatalf("unexpected order: %#v", results)
+	}
+	if results[0].UserRatingCount == nil || *results[0].UserRatingCount != 2000 {
+		t.Fatalf("expected user_rating_count in output: %#v", results[0])
+	}
+}
+
+func TestRunSearchSortDistance(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [
+			{"id": "far", "location": {"latitude": 40.9, "longitude": -74}},
+			{"id": "near", "location": {"latitude": 40.71, "longitude": -74}}
+		]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"search", "coffee",
+		"--sort", "distance",
+		"--lat", "40.7", "--lng=-74",
+		"--api-key", "x",
+		"--base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var results []goplaces.PlaceSummary
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(results) != 2 || results[0].PlaceID != "near" {
+		t.Fatalf("unexpected order: %#v", results)
+	}
+}
+
+func TestRunSearchSortDistanceNeedsCenter(t *testing.T) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"search", "coffee",
+		"--sort", "distance",
+		"--api-key", "x",
+		"--base-url", "http://127.0.0.1:1",
+	}, &stdout, &stderr)
+	if exitCode != 2 {
+		t.Fatalf("expected exit code 2, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "distance requires --lat/--lng") {
+		t.Fatalf("unexpected stderr: %s", stderr.String())
+	}
+}
+
+func TestRunSearchAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch body["pageToken"] {
+		case nil:
+			_, _ = w.Write([]byte(`{"places": [{"id": "a"}], "nextPageToken": "p2"}`))
+		case "p2":
+			_, _ = w.Write([]byte(`{"places": [{"id": "a"}, {"id": "b"}], "nextPageToken": "p3"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("boom"))
+		}
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"search", "coffee",
+		"--all", "--max-pages", "2",
+		"--api-key", "x",
+		"--base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var results []goplaces.PlaceSummary
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if len(results) != 2 || results[0].PlaceID != "a" || results[1].PlaceID != "b" {
+		t.Fatalf("unexpected merged results: %#v", results)
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	exitCode = Run([]string{
+		"search", "coffee",
+		"--all",
+		"--api-key", "x",
+		"--base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+	if exitCode != 1 {
+		t.Fatalf("expected exit code 1 for partial failure, got %d", exitCode)
+	}
+	if !strings.Contains(stdout.String(), `"place_id": "b"`) || !strings.Contains(stderr.String(), "page 3") {
+		t.Fatalf("expected partial output and error, stdout=%s stderr=%s", stdout.String(), stderr.String())
+	}
+}
+
+func TestChainMatcher(t *testing.T) {
+	matcher := parseChainList(defaultChainList)
+	for _, name := range []string{"Starbucks", "STARBUCKS Reserve", "McDonald’s", "Dunkin'"} {
+		if !matcher.isChain(name) {
+			t.Fatalf("expected %q to match a chain", name)
+		}
+	}
+	for _, name := range []string{"Joe's Corner Cafe", "Subwayside Diner", ""} {
+		if matcher.isChain(name) {
+			t.Fatalf("expected %q to be local", name)
+		}
+	}
+}
+
+func TestRunSearchLocalOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [
+			{"id": "chain", "displayName": {"text": "Starbucks"}},
+			{"id": "local", "displayName": {"text": "Joe's Corner Cafe"}},
+			{"id": "custom", "displayName": {"text": "Bean Barn #12"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	run := func(extra ...string) []goplaces.PlaceSummary {
+		t.Helper()
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		args := append([]string{"search", "coffee", "--api-key", "x", "--base-url", server.URL, "--json"}, extra...)
+		if exitCode := Run(args, &stdout, &stderr); exitCode != 0 {
+			t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+		}
+		var results []goplaces.PlaceSummary
+		if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+			t.Fatalf("decode output: %v", err)
+		}
+		return results
+	}
+
+	results := run("--local-only")
+	if len(results) != 2 || results[0].PlaceID != "local" || results[1].PlaceID != "custom" {
+		t.Fatalf("unexpected local-only results: %#v", results)
+	}
+
+	path := filepath.Join(t.TempDir(), "chains.txt")
+	if err := os.WriteFile(path, []byte("# mine\nBean Barn\n"), 0o600); err != nil {
+		t.Fatalf("write chain list: %v", err)
+	}
+	results = run("--chain-list", path)
+	if len(results) != 2 || results[0].PlaceID != "chain" || results[1].PlaceID != "local" {
+		t.Fatalf("unexpected custom chain list results: %#v", results)
+	}
+}
+
+func TestRunSearchBBox(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"search", "coffee",
+		"--bbox=-122.5,47.5,-122.2,47.7",
+		"--api-key", "x",
+		"--base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	bias, _ := gotBody["locationBias"].(map[string]any)
+	rectangle, _ := bias["rectangle"].(map[string]any)
+	low, _ := rectangle["low"].(map[string]any)
+	high, _ := rectangle["high"].(map[string]any)
+	if low["latitude"] != 47.5 || low["longitude"] != -122.5 || high["latitude"] != 47.7 || high["longitude"] != -122.2 {
+		t.Fatalf("unexpected rectangle bias: %#v", gotBody["locationBias"])
+	}
+
+	for _, args := range [][]string{
+		{"search", "coffee", "--bbox", "1,2,3"},
+		{"search", "coffee", "--bbox=-122.5,47.7,-122.2,47.5"},
+		{"autocomplete", "cof", "--bbox", "1,2,3,4", "--lat", "1"},
+	} {
+		stdout.Reset()
+		stderr.Reset()
+		if exitCode := Run(append(args, "--api-key", "x"), &stdout, &stderr); exitCode != 2 {
+			t.Fatalf("%v: expected validation exit code 2, got %d (stderr=%s)", args, exitCode, stderr.String())
+		}
+	}
+}
+
+func TestRunNearbyRankBy(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"nearby", "--lat", "1", "--lng", "2", "--radius-m", "100",
+		"--rank-by", "popularity",
+		"--api-key", "x", "--base-url", server.URL, "--json",
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if gotBody["rankPreference"] != "POPULARITY" {
+		t.Fatalf("expected POPULARITY, got %#v", gotBody["rankPreference"])
+	}
+
+	exitCode = Run([]string{
+		"nearby", "--lat", "1", "--lng", "2", "--radius-m", "100",
+		"--rank-by", "relevance", "--api-key", "x",
+	}, &stdout, &stderr)
+	if exitCode == 0 {
+		t.Fatalf("expected enum rejection for relevance")
+	}
+}
+
+func TestRunNearbyListTypes(t *testing.T) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	if exitCode := Run([]string{"nearby", "--list-types"}, &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != len(goplaces.PlaceTypes()) || !slices.Contains(lines, "cafe") {
+		t.Fatalf("unexpected type list: %q", stdout.String())
+	}
+}
+
+func TestRunNearbyPrimaryTypes(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"nearby", "--lat", "1", "--lng", "2", "--radius-m", "100",
+		"--included-primary-type", "cafe", "--excluded-primary-type", "gas_station",
+		"--api-key", "x", "--base-url", server.URL, "--json",
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if included, _ := gotBody["includedPrimaryTypes"].([]any); len(included) != 1 || included[0] != "cafe" {
+		t.Fatalf("unexpected includedPrimaryTypes: %#v", gotBody["includedPrimaryTypes"])
+	}
+	if excluded, _ := gotBody["excludedPrimaryTypes"].([]any); len(excluded) != 1 || excluded[0] != "gas_station" {
+		t.Fatalf("unexpected excludedPrimaryTypes: %#v", gotBody["excludedPrimaryTypes"])
+	}
+
+	stderr.Reset()
+	exitCode = Run([]string{
+		"nearby", "--lat", "1", "--lng", "2", "--radius-m", "100",
+		"--primary-type", "cafe", "--excluded-primary-type", "cafe",
+		"--api-key", "x", "--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 2 || !strings.Contains(stderr.String(), "excluded_primary_types") {
+		t.Fatalf("expected overlap validation error, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+}
+
+func TestRunSearchFormatTSV(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [
+			{"id": "abc", "displayName": {"text": "Tab\tCafe"}, "formattedAddress": "1 Main St, Town",
+			 "location": {"latitude": 47.6, "longitude": -122.3}, "rating": 4.5, "userRatingCount": 12,
+			 "priceLevel": "PRICE_LEVEL_MODERATE", "types": ["cafe", "food"]},
+			{"id": "def"}
+		]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"search", "coffee", "--format", "tsv",
+		"--api-key", "x", "--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header + 2 rows, got %q", stdout.String())
+	}
+	for _, line := range lines {
+		if got := len(strings.Split(line, "\t")); got != len(placeColumns) {
+			t.Fatalf("expected %d columns, got %d in %q", len(placeColumns), got, line)
+		}
+	}
+	if lines[0] != strings.Join(placeColumns, "\t") {
+		t.Fatalf("unexpected header: %q", lines[0])
+	}
+	want := "abc\tTab\\tCafe\t1 Main St, Town\t47.6\t-122.3\t4.5\t12\t2\tcafe;food"
+	if lines[1] != want {
+		t.Fatalf("unexpected row:\n%q\nwant:\n%q", lines[1], want)
+	}
+
+	exitCode = Run([]string{"search", "coffee", "--format", "tsv", "--json", "--api-key", "x"}, &stdout, &stderr)
+	if exitCode != 2 {
+		t.Fatalf("expected validation error for --json with --format, got %d", exitCode)
+	}
+}
+
+func TestRunSearchFormatTable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [
+			{"id": "abc", "displayName": {"text": "Tab\tCafe"}, "rating": 4.5, "priceLevel": "PRICE_LEVEL_MODERATE",
+			 "types": ["cafe", "food"],
+			 "formattedAddress": "1 Main Street, Some Very Long Neighbourhood Name, Springfield, Oregon 97403, United States"},
+			{"id": "def", "displayName": {"text": "Bar"}, "formattedAddress": "2 Side St"}
+		]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"search", "coffee", "--format", "table",
+		"--api-key", "x", "--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header + 2 rows, got %q", stdout.String())
+	}
+	if !strings.HasPrefix(lines[0], "#  Name      Rating  Price  Types       Address") {
+		t.Fatalf("unexpected header: %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "1  Tab Cafe  4.5     $2     cafe, food  1 Main Street") || !strings.HasSuffix(lines[1], "…") {
+		t.Fatalf("unexpected row: %q", lines[1])
+	}
+	if width := utf8.RuneCountInString(lines[1]); width != defaultTableWidth {
+		t.Fatalf("expected the row cut to %d columns, got %d: %q", defaultTableWidth, width, lines[1])
+	}
+	address := strings.Index(lines[0], "Address")
+	if !strings.HasPrefix(lines[2][address:], "2 Side St") {
+		t.Fatalf("columns not aligned: %q", lines[2])
+	}
+}
+
+func TestRunSearchFormatCSV(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["pageToken"] == "p2" {
+			_, _ = w.Write([]byte(`{"places": [{"id": "def", "displayName": {"text": "Plain"}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"places": [
+			{"id": "abc", "displayName": {"text": "Cafe \"Uno\""}, "formattedAddress": "1 Main St, Town",
+			 "location": {"latitude": 47.6, "longitude": -122.3}, "rating": 4.5, "types": ["cafe", "food"]}
+		], "nextPageToken": "p2"}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"search", "coffee", "--format", "csv", "--all",
+		"--api-key", "x", "--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	want := "place_id,name,address,lat,lng,rating,user_rating_count,price_level,types\n" +
+		"abc,\"Cafe \"\"Uno\"\"\",\"1 Main St, Town\",47.6,-122.3,4.5,,,cafe;food\n" +
+		"def,Plain,,,,,,,\n"
+	if stdout.String() != want {
+		t.Fatalf("unexpected csv:\n%s\nwant:\n%s", stdout.String(), want)
+	}
+}
+
+func TestRunResolveFormatCSV(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [{"id": "loc", "displayName": {"text": "Riverside Park"}, "types": ["park"]}]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"resolve", "Riverside Park", "--format", "csv",
+		"--api-key", "x", "--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 || lines[1] != "loc,Riverside Park,,,,,,,park" {
+		t.Fatalf("unexpected resolve csv: %q", stdout.String())
+	}
+}
+
+func TestRunFormatListDelimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [{"id": "abc", "types": ["cafe", "food"]}]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"search", "coffee", "--format", "csv", "--no-header", "--list-delimiter", "|",
+		"--api-key", "x", "--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if got := stdout.String(); got != "abc,,,,,,,,cafe|food\n" {
+		t.Fatalf("unexpected csv: %q", got)
+	}
+
+	for _, args := range [][]string{
+		{"--format", "csv", "--list-delimiter", ","},
+		{"--format", "tsv", "--list-delimiter", "\t"},
+	} {
+		stderr.Reset()
+		exitCode = Run(append([]string{"search", "coffee", "--api-key", "x"}, args...), &bytes.Buffer{}, &stderr)
+		if exitCode != 2 || !strings.Contains(stderr.String(), "column delimiter") {
+			t.Fatalf("expected validation error for %v, got %d (stderr=%s)", args, exitCode, stderr.String())
+		}
+	}
+}
+
+func TestRunFormatNoHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [{"id": "abc", "displayName": {"text": "Cafe"}}]}`))
+	}))
+	defer server.Close()
+
+	header := strings.Join(placeColumns, ",")
+	for _, tc := range []struct {
+		format string
+		header string
+	}{
+		{"csv", header},
+		{"tsv", strings.Join(placeColumns, "\t")},
+	} {
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		args := []string{"search", "coffee", "--format", tc.format, "--api-key", "x", "--base-url", server.URL}
+		if exitCode := Run(args, &stdout, &stderr); exitCode != 0 {
+			t.Fatalf("%s: expected exit code 0, got %d (stderr=%s)", tc.format, exitCode, stderr.String())
+		}
+		if !strings.HasPrefix(stdout.String(), tc.header+"\n") {
+			t.Fatalf("%s: expected header by default, got %q", tc.format, stdout.String())
+		}
+
+		stdout.Reset()
+		if exitCode := Run(append(args, "--no-header"), &stdout, &stderr); exitCode != 0 {
+			t.Fatalf("%s: expected exit code 0, got %d (stderr=%s)", tc.format, exitCode, stderr.String())
+		}
+		if strings.Contains(stdout.String(), "place_id") || !strings.HasPrefix(stdout.String(), "abc") {
+			t.Fatalf("%s: expected no header, got %q", tc.format, stdout.String())
+		}
+	}
+}
+
+func TestRunVerboseAndQuiet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [], "nextPageToken": "tok"}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"search", "coffee",
+		"--json", "--verbose",
+		"--api-key", "secret-key",
+		"--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	logs := stderr.String()
+	if !strings.Contains(logs, "level=DEBUG") || !strings.Contains(logs, "method=POST") || !strings.Contains(logs, "status=200") {
+		t.Fatalf("expected request log, got %q", logs)
+	}
+	if strings.Contains(logs, "secret-key") {
+		t.Fatalf("API key leaked into log: %q", logs)
+	}
+	if !strings.Contains(logs, "next_page_token: tok") {
+		t.Fatalf("expected page token notice, got %q", logs)
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	exitCode = Run([]string{
+		"search", "coffee",
+		"--json", "--quiet",
+		"--api-key", "x",
+		"--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if stderr.Len() != 0 {
+		t.Fatalf("expected no stderr with --quiet, got %q", stderr.String())
+	}
+}
+
+func TestRunDryRun(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"--dry-run", "search", "coffee", "--keyword", "vegan", "--limit", "3",
+		"--api-key", "secret-key", "--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if requests != 0 {
+		t.Fatalf("--dry-run should not call the API, got %d requests", requests)
+	}
+	if strings.Contains(stdout.String(), "secret-key") {
+		t.Fatalf("dry run leaked the API key: %s", stdout.String())
+	}
+	var description goplaces.RequestDescription
+	if err := json.Unmarshal(stdout.Bytes(), &description); err != nil {
+		t.Fatalf("decode dry run: %v (stdout=%s)", err, stdout.String())
+	}
+	if description.Method != http.MethodPost || description.URL != server.URL+"/places:searchText" {
+		t.Fatalf("unexpected endpoint: %s %s", description.Method, description.URL)
+	}
+	if description.Body["textQuery"] != "coffee vegan" || description.Body["pageSize"] != float64(3) {
+		t.Fatalf("unexpected body: %#v", description.Body)
+	}
+	if !strings.HasPrefix(description.FieldMask, "places.id,") {
+		t.Fatalf("unexpected field mask: %s", description.FieldMask)
+	}
+
+	stdout.Reset()
+	exitCode = Run([]string{
+		"--dry-run", "route", "coffee", "--from", "Seattle", "--to", "Portland", "--avoid-tolls",
+		"--api-key", "secret-key", "--routes-base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 || requests != 0 {
+		t.Fatalf("expected a route dry run, got exit %d with %d requests", exitCode, requests)
+	}
+	if !strings.Contains(stdout.String(), `"avoidTolls": true`) {
+		t.Fatalf("expected the computeRoutes body: %s", stdout.String())
+	}
+
+	stdout.Reset()
+	exitCode = Run([]string{
+		"--dry-run", "doctor", "--api-key", "secret-key", "--base-url", server.URL, "--routes-base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 || requests != 0 {
+		t.Fatalf("expected a doctor dry run, got exit %d with %d requests", exitCode, requests)
+	}
+	var probes []goplaces.RequestDescription
+	if err := json.Unmarshal(stdout.Bytes(), &probes); err != nil {
+		t.Fatalf("decode doctor dry run: %v (stdout=%s)", err, stdout.String())
+	}
+	if len(probes) != 2 || probes[0].URL != server.URL+"/places:searchText" || probes[0].FieldMask != "places.id" ||
+		probes[1].Method != http.MethodGet || probes[1].URL != server.URL+"/" {
+		t.Fatalf("unexpected probe descriptions: %+v", probes)
+	}
+	if strings.Contains(stdout.String(), "secret-key") {
+		t.Fatalf("doctor dry run leaked the API key: %s", stdout.String())
+	}
+
+	// --dry-run still runs the flag-conflict checks.
+	for _, args := range [][]string{
+		{"search", "coffee", "--slim", "--ids-only"},
+		{"search", "coffee", "--raw", "--all"},
+		{"nearby", "--lat", "1", "--lng", "2", "--radius-m", "3", "--raw", "--grid"},
+	} {
+		stdout.Reset()
+		exitCode = Run(append([]string{"--dry-run", "--api-key", "secret-key", "--base-url", server.URL}, args...), &stdout, &stderr)
+		if exitCode != 2 || stdout.Len() != 0 {
+			t.Fatalf("%v: expected a validation error, got exit %d (stdout=%s)", args, exitCode, stdout.String())
+		}
+	}
+}
+
+func TestRunDetailsPrintFieldMask(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"id": "abc", "displayName": {"text": "Cafe"}}`))
+	}))
+	defer server.Close()
+
+	want := "id,displayName,formattedAddress,location,rating,userRatingCount,priceLevel,priceRange,types," +
+		"regularOpeningHours,currentOpeningHours,businessStatus,utcOffsetMinutes,plusCode," +
+		"nationalPhoneNumber,internationalPhoneNumber,websiteUri,reviews,photos"
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{
+		"details", "abc", "--reviews", "--photos", "--dry-run",
+		"--api-key", "x", "--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var description goplaces.RequestDescription
+	if err := json.Unmarshal(stdout.Bytes(), &description); err != nil {
+		t.Fatalf("decode dry run: %v (stdout=%s)", err, stdout.String())
+	}
+	if description.FieldMask != want {
+		t.Fatalf("unexpected field mask:\n got %s\nwant %s", description.FieldMask, want)
+	}
+	if requests != 0 {
+		t.Fatalf("--dry-run should not call the API, got %d requests", requests)
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	exitCode = Run([]string{
+		"details", "abc", "--reviews", "--photos", "--print-field-mask", "--json",
+		"--api-key", "x", "--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if got := strings.TrimSpace(stderr.String()); got != "field_mask: "+want {
+		t.Fatalf("unexpected stderr: %q", got)
+	}
+	if requests != 1 || !strings.Contains(stdout.String(), `"place_id": "abc"`) {
+		t.Fatalf("expected the request to run, got %d requests (stdout=%s)", requests, stdout.String())
+	}
+}
@@ -35,6 +35,11 @@ func (c Color) Yellow(value string) string {
 	return c.wrap("33", value)
 }
 
+// Red wraps a string in red ANSI codes.
+func (c Color) Red(value string) string {
+	return c.wrap("31", value)
+}
+
 // Dim wraps a string in dim ANSI codes.
 func (c Color) Dim(value string) string {
 	return c.wrap("2", value)
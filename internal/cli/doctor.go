@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/steipete/goplaces"
+)
+
+const maxClockSkew = 5 * time.Minute
+
+const (
+	checkOK   = "ok"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// DoctorCmd diagnoses common setup issues.
+type DoctorCmd struct{}
+
+type doctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Fix    string `json:"fix,omitempty"`
+}
+
+// Run executes the doctor command.
+func (c *DoctorCmd) Run(app *App) error {
+	if app.dryRun {
+		places, err := app.client.DescribeProbePlaces()
+		if err != nil {
+			return err
+		}
+		return writeJSON(app.out, []goplaces.RequestDescription{places, app.client.DescribeProbeRoutes()})
+	}
+	ctx, cancel := app.deadline(context.Background())
+	defer cancel()
+	checks := make([]doctorCheck, 0, 4)
+
+	// One live Places call covers key presence, enablement, and reachability.
+	places, err := app.client.ProbePlaces(ctx)
+	switch {
+	case errors.Is(err, goplaces.ErrMissingAPIKey):
+		checks = append(checks, doctorCheck{
+			Name:   "API key",
+			Status: checkFail,
+			Detail: "not set",
+			Fix:    "Set GOOGLE_PLACES_API_KEY or pass --api-key.",
+		})
+	case err != nil:
+		checks = append(checks,
+			doctorCheck{Name: "API key", Status: checkOK, Detail: "present"},
+			doctorCheck{
+				Name:   "Places API",
+				Status: checkFail,
+				Detail: err.Error(),
+				Fix:    "Check network access and --base-url / GOOGLE_PLACES_BASE_URL.",
+			},
+		)
+	default:
+		checks = append(checks,
+			doctorCheck{Name: "API key", Status: checkOK, Detail: "present"},
+			placesStatusCheck(places),
+		)
+		if skew := clockSkewCheck(places.ServerTime, time.Now()); skew != nil {
+			checks = append(checks, *skew)
+		}
+	}
+
+	routes, err := app.client.ProbeRoutes(ctx)
+	if err != nil {
+		checks = append(checks, doctorCheck{
+			Name:   "Routes API",
+			Status: checkWarn,
+			Detail: err.Error(),
+			Fix:    "The route command needs the Routes API; check --routes-base-url / GOOGLE_ROUTES_BASE_URL.",
+		})
+	} else {
+		checks = append(checks, doctorCheck{
+			Name:   "Routes API",
+			Status: checkOK,
+			Detail: fmt.Sprintf("reachable (HTTP %d)", routes.StatusCode),
+		})
+	}
+
+	if app.json {
+		if err := writeJSON(app.out, checks); err != nil {
+			return err
+		}
+	} else if _, err := fmt.Fprintln(app.out, renderDoctor(app.color, checks)); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, check := range checks {
+		if check.Status == checkFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("doctor: %d check(s) failed", failed)
+	}
+	return nil
+}
+
+func placesStatusCheck(result goplaces.ProbeResult) doctorCheck {
+	check := doctorCheck{Name: "Places API", Detail: fmt.Sprintf("HTTP %d", result.StatusCode)}
+	switch {
+	case result.StatusCode < http.StatusBadRequest:
+		check.Status = checkOK
+		check.Detail = fmt.Sprintf("HTTP %d in %s", result.StatusCode, result.Latency.Round(time.Millisecond))
+	case result.StatusCode == http.StatusUnauthorized || result.StatusCode == http.StatusForbidden:
+		check.Status = checkFail
+		check.Fix = "Enable Places API (New) in Cloud Console and check the key's API restrictions."
+	case result.StatusCode == http.StatusBadRequest:
+		check.Status = checkFail
+		check.Fix = "The API key looks invalid; create a new key under APIs & Services → Credentials."
+	default:
+		check.Status = checkWarn
+		check.Fix = "Google returned an unexpected status; retry later."
+	}
+	return check
+}
+
+func clockSkewCheck(server time.Time, local time.Time) *doctorCheck {
+	if server.IsZero() {
+		return nil
+	}
+	skew := local.Sub(server)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew <= maxClockSkew {
+		return &doctorCheck{Name: "Clock", Status: checkOK, Detail: "in sync"}
+	}
+	return &doctorCheck{
+		Name:   "Clock",
+		Status: checkWarn,
+		Detail: fmt.Sprintf("off by %s", skew.Round(time.Second)),
+		Fix:    "Sync the system clock (NTP); skew breaks departure times and token expiry.",
+	}
+}
+
+func renderDoctor(color Color, checks []doctorCheck) string {
+	var out bytes.Buffer
+	out.WriteString(color.Bold("Doctor"))
+	out.WriteString("\n")
+	for _, check := range checks {
+		var status string
+		switch check.Status {
+		case checkOK:
+			status = color.Green("[ok]  ")
+		case checkWarn:
+			status = color.Yellow("[warn]")
+		default:
+			status = color.Red("[fail]")
+		}
+		out.WriteString(status)
+		out.WriteString(" ")
+		out.WriteString(check.Name)
+		if check.Detail != "" {
+			out.WriteString(color.Dim(" — " + check.Detail))
+		}
+		out.WriteString("\n")
+		if check.Fix != "" {
+			out.WriteString("       ")
+			out.WriteString(check.Fix)
+			out.WriteString("\n")
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/steipete/goplaces"
+)
+
+// languageRegions maps language codes that are not CLDR regions to the
+// region they are most often meant with ("--region en" → US).
+var languageRegions = map[string]string{
+	"en": "US",
+	"ja": "JP",
+	"ko": "KR",
+	"zh": "CN",
+	"da": "DK",
+	"cs": "CZ",
+	"el": "GR",
+	"he": "IL",
+	"hi": "IN",
+	"uk": "UA",
+	"fa": "IR",
+	"ur": "PK",
+	"nb": "NO",
+	"hy": "AM",
+	"ka": "GE",
+}
+
+// regionLanguages maps region codes that are not language codes to their
+// main language ("--language JP" → ja).
+var regionLanguages = map[string]string{
+	"us": "en",
+	"gb": "en",
+	"au": "en",
+	"jp": "ja",
+	"kr": "ko",
+	"cn": "zh",
+	"dk": "da",
+	"cz": "cs",
+	"gr": "el",
+	"il": "he",
+	"ua": "uk",
+	"vn": "vi",
+	"ir": "fa",
+	"mx": "es",
+	"at": "de",
+}
+
+// localePattern is a --locale value: language and region joined by "-" or
+// "_" (en-US, pt_BR).
+var localePattern = regexp.MustCompile(`^([A-Za-z]{2,3})[-_]([A-Za-z]{2})$`)
+
+// parseLocale splits --locale into a language and a region code.
+func parseLocale(locale string) (string, string, error) {
+	match := localePattern.FindStringSubmatch(strings.TrimSpace(locale))
+	if match == nil {
+		return "", "", goplaces.ValidationError{
+			Field:   "locale",
+			Message: fmt.Sprintf("must be language-REGION like en-US or pt-BR: got %q", locale),
+		}
+	}
+	return strings.ToLower(match[1]), strings.ToUpper(match[2]), nil
+}
+
+// normalizeLocale trims --language/--region, fills gaps from --locale, and
+// warns on stderr when one looks like it was meant for the other. Values
+// are passed through unchanged otherwise; the client's validateLocale
+// rejects malformed codes before any request is sent.
+func normalizeLocale(app *App, language, region string) (string, string) {
+	language = strings.TrimSpace(language)
+	region = strings.TrimSpace(region)
+	// --locale fills in whatever --language/--region leave empty.
+	if language == "" {
+		language = app.language
+	}
+	if region == "" {
+		region = app.region
+	}
+
+	if suggested, ok := languageRegions[strings.ToLower(region)]; ok {
+		_, _ = fmt.Fprintf(app.err, "warning: --region %s looks like a language code; did you mean --language %s --region %s?\n",
+			region, strings.ToLower(region), suggested)
+	}
+	if suggested, ok := regionLanguages[strings.ToLower(language)]; ok {
+		_, _ = fmt.Fprintf(app.err, "warning: --language %s looks like a region code; did you mean --language %s --region %s?\n",
+			language, suggested, strings.ToUpper(language))
+	}
+	return language, region
+}
+
+// noteNameLanguage tells human readers when returned place names are not in
+// the requested --language (the API falls back when no translation exists).
+func noteNameLanguage(app *App, requested string, nameLanguages ...string) {
+	want := baseLanguage(requested)
+	if want == "" {
+		return
+	}
+	var other []string
+	for _, lang := range nameLanguages {
+		if got := baseLanguage(lang); got != "" && got != want {
+			other = append(other, lang)
+		}
+	}
+	switch {
+	case len(other) == 0:
+		return
+	case len(nameLanguages) == 1:
+		app.notef("note: place name is in %s, not %s", other[0], requested)
+	default:
+		app.notef("note: %d of %d place names are not in %s", len(other), len(nameLanguages), requested)
+	}
+}
+
+// baseLanguage returns the lower-cased primary subtag ("en-US" → "en").
+func baseLanguage(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+func summaryNameLanguages(results []goplaces.PlaceSummary) []string {
+	languages := make([]string, 0, len(results))
+	for _, place := range results {
+		languages = append(languages, place.NameLanguage)
+	}
+	return languages
+}
@@ -0,0 +1,287 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/steipete/goplaces"
+)
+
+const (
+	formatHuman = "human"
+	formatTSV   = "tsv"
+	formatCSV   = "csv"
+	formatTable = "table"
+)
+
+// ListOutput selects how place lists are written when --json is not set.
+type ListOutput struct {
+	Format        string `help:"Output format: human, table (aligned columns), tsv, csv." enum:"human,table,tsv,csv" default:"human"`
+	NoHeader      bool   `help:"Omit the csv/tsv header row (e.g. when appending to a file)."`
+	ListDelimiter string `help:"Separator for multi-valued csv/tsv cells such as types." name:"list-delimiter" default:";"`
+	GeoLocation   bool   `help:"JSON with each location as a GeoJSON Point ([lng, lat]; implies --json)." name:"geo-location"`
+	First         bool   `help:"Output only the top result (after sorting); JSON is a single object. Fails when there are no results."`
+	IDsOnly       bool   `help:"Print only place IDs, one per line (a JSON array of strings with --json)." name:"ids-only"`
+}
+
+// tabular reports whether a row format (tsv, csv, or table) was requested.
+func (o ListOutput) tabular() bool {
+	return o.Format != "" && o.Format != formatHuman
+}
+
+// check rejects combining a row format with --json or --geo-location.
+func (o ListOutput) check(app *App) error {
+	if o.tabular() && app.json {
+		return goplaces.ValidationError{Field: "format", Message: "use either --json or --format " + o.Format}
+	}
+	if o.tabular() && o.GeoLocation {
+		return goplaces.ValidationError{Field: "geo_location", Message: "use either --geo-location or --format " + o.Format}
+	}
+	if o.tabular() && o.Format != formatTable && (o.ListDelimiter == "" || o.ListDelimiter == o.columnDelimiter()) {
+		return goplaces.ValidationError{
+			Field:   "list_delimiter",
+			Message: fmt.Sprintf("must be non-empty and differ from the %s column delimiter", o.Format),
+		}
+	}
+	if o.IDsOnly && (o.tabular() || o.GeoLocation) {
+		return goplaces.ValidationError{Field: "ids_only", Message: "--ids-only cannot be combined with --format or --geo-location"}
+	}
+	return nil
+}
+
+// columnDelimiter is the field separator of the selected row format.
+func (o ListOutput) columnDelimiter() string {
+	if o.Format == formatTSV {
+		return "\t"
+	}
+	return ","
+}
+
+// checkRaw rejects list options that need mapped results.
+func (o ListOutput) checkRaw() error {
+	if o.tabular() || o.GeoLocation || o.First || o.IDsOnly {
+		return goplaces.ValidationError{Field: "raw", Message: "--raw cannot be combined with --format, --geo-location, --first, or --ids-only"}
+	}
+	return nil
+}
+
+// write emits the header row once (unless --no-header), followed by one row
+// per place. Callers pass the complete (already merged) result set.
+func (o ListOutput) write(out io.Writer, places []goplaces.PlaceSummary) error {
+	if o.Format == formatTable {
+		return writeTable(out, places, !o.NoHeader)
+	}
+	rows := make([][]string, 0, len(places)+1)
+	if !o.NoHeader {
+		rows = append(rows, placeColumns)
+	}
+	for _, place := range places {
+		rows = append(rows, placeRow(place, o.ListDelimiter))
+	}
+	switch o.Format {
+	case formatTSV:
+		return writeTSV(out, rows)
+	case formatCSV:
+		return writeCSV(out, rows)
+	}
+	return fmt.Errorf("unsupported format %q", o.Format)
+}
+
+// writeIDs prints one place ID per line for --ids-only, or a JSON array
+// (a single string with --first) when --json is set.
+func (o ListOutput) writeIDs(app *App, places []goplaces.PlaceSummary) error {
+	ids := make([]string, 0, len(places))
+	for _, place := range places {
+		ids = append(ids, place.PlaceID)
+	}
+	if app.json {
+		return writeJSON(app.out, listJSON(o.First, ids))
+	}
+	return writeLines(app, ids)
+}
+
+// writeLines prints values one per line, or as a JSON array with --json.
+func writeLines(app *App, values []string) error {
+	if app.json {
+		return writeJSON(app.out, values)
+	}
+	for _, value := range values {
+		if _, err := fmt.Fprintln(app.out, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SlimPlace is the --slim JSON projection of a place: just enough to
+// identify and rank it.
+type SlimPlace struct {
+	PlaceID string   `json:"place_id"`
+	Name    string   `json:"name,omitempty"`
+	Lat     *float64 `json:"lat,omitempty"`
+	Lng     *float64 `json:"lng,omitempty"`
+	Rating  *float64 `json:"rating,omitempty"`
+}
+
+func slimPlace(place goplaces.PlaceSummary) SlimPlace {
+	slim := SlimPlace{PlaceID: place.PlaceID, Name: place.Name, Rating: place.Rating}
+	if place.Location != nil {
+		slim.Lat = &place.Location.Lat
+		slim.Lng = &place.Location.Lng
+	}
+	return slim
+}
+
+func slimPlaces(places []goplaces.PlaceSummary) []SlimPlace {
+	slim := make([]SlimPlace, 0, len(places))
+	for _, place := range places {
+		slim = append(slim, slimPlace(place))
+	}
+	return slim
+}
+
+// GeoPoint is a GeoJSON Point geometry. Coordinates are [lng, lat], the
+// order GeoJSON requires.
+type GeoPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+func geoPoint(loc *goplaces.LatLng) *GeoPoint {
+	if loc == nil {
+		return nil
+	}
+	return &GeoPoint{Type: "Point", Coordinates: [2]float64{loc.Lng, loc.Lat}}
+}
+
+// geoPlace and geoResolved shadow the embedded location with a GeoPoint for
+// --geo-location; every other field is encoded as usual.
+type geoPlace struct {
+	goplaces.PlaceSummary
+	Location *GeoPoint `json:"location,omitempty"`
+}
+
+type geoResolved struct {
+	goplaces.ResolvedLocation
+	Location *GeoPoint `json:"location,omitempty"`
+}
+
+// placesJSON returns places as-is, or with GeoJSON locations for --geo-location.
+func (o ListOutput) placesJSON(places []goplaces.PlaceSummary) any {
+	if !o.GeoLocation {
+		return listJSON(o.First, places)
+	}
+	geo := make([]geoPlace, 0, len(places))
+	for _, place := range places {
+		geo = append(geo, geoPlace{PlaceSummary: place, Location: geoPoint(place.Location)})
+	}
+	return listJSON(o.First, geo)
+}
+
+// resolvedJSON is placesJSON for resolve results.
+func (o ListOutput) resolvedJSON(places []goplaces.ResolvedLocation) any {
+	if !o.GeoLocation {
+		return listJSON(o.First, places)
+	}
+	geo := make([]geoResolved, 0, len(places))
+	for _, place := range places {
+		geo = append(geo, geoResolved{ResolvedLocation: place, Location: geoPoint(place.Location)})
+	}
+	return listJSON(o.First, geo)
+}
+
+// firstResult keeps only the top entry for --first, failing when there is
+// none so scripts can branch on the exit code.
+func firstResult[T any](first bool, items []T) ([]T, error) {
+	if !first {
+		return items, nil
+	}
+	if len(items) == 0 {
+		return nil, goplaces.ErrNoPlaceFound
+	}
+	return items[:1], nil
+}
+
+// listJSON unwraps the single --first entry so JSON is an object, not an array.
+func listJSON[T any](first bool, items []T) any {
+	if first && len(items) == 1 {
+		return items[0]
+	}
+	return items
+}
+
+var placeColumns = []string{
+	"place_id", "name", "address", "lat", "lng", "rating", "user_rating_count", "price_level", "types",
+}
+
+// placeRow flattens a place into placeColumns order. Missing values are
+// empty cells; types are joined with listDelim (--list-delimiter, ";" by
+// default), which check keeps distinct from the field delimiter.
+func placeRow(place goplaces.PlaceSummary, listDelim string) []string {
+	row := []string{place.PlaceID, place.Name, place.Address, "", "", "", "", "", strings.Join(place.Types, listDelim)}
+	if place.Location != nil {
+		row[3] = formatFloat(place.Location.Lat)
+		row[4] = formatFloat(place.Location.Lng)
+	}
+	if place.Rating != nil {
+		row[5] = formatFloat(*place.Rating)
+	}
+	if place.UserRatingCount != nil {
+		row[6] = strconv.Itoa(*place.UserRatingCount)
+	}
+	if place.PriceLevel != nil {
+		row[7] = strconv.Itoa(*place.PriceLevel)
+	}
+	return row
+}
+
+// resolvedPlaces adapts resolve results to the shared place columns.
+func resolvedPlaces(results []goplaces.ResolvedLocation) []goplaces.PlaceSummary {
+	places := make([]goplaces.PlaceSummary, 0, len(results))
+	for _, result := range results {
+		places = append(places, goplaces.PlaceSummary{
+			PlaceID:  result.PlaceID,
+			Name:     result.Name,
+			Address:  result.Address,
+			Location: result.Location,
+			Types:    result.Types,
+		})
+	}
+	return places
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// tsvEscaper keeps one record per line and one field per tab.
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// writeTSV writes unquoted, unaligned tab-separated rows for cut/awk.
+func writeTSV(out io.Writer, rows [][]string) error {
+	for _, row := range rows {
+		fields := make([]string, len(row))
+		for i, field := range row {
+			fields[i] = tsvEscaper.Replace(field)
+		}
+		if _, err := fmt.Fprintln(out, strings.Join(fields, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaskOutput shows the field mask a command sends, which decides what the
+// request is billed for.
+type MaskOutput struct {
+	PrintFieldMask bool `help:"Print the X-Goog-FieldMask sent by this command to stderr." name:"print-field-mask"`
+}
+
+// reportMask prints mask to stderr for --print-field-mask.
+func (m MaskOutput) reportMask(app *App, mask string) {
+	if m.PrintFieldMask {
+		_, _ = fmt.Fprintln(app.err, "field_mask:", mask)
+	}
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steipete/goplaces"
+)
+
+// photoExtensions prefers the common spelling over mime's first match.
+var photoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+// downloadPhoto streams a photo into path via a temp file in the same
+// directory, appending an extension from the content type when path has
+// none. It returns the final path.
+func downloadPhoto(ctx context.Context, app *App, request goplaces.PhotoMediaRequest, path string) (string, goplaces.PhotoDownload, error) {
+	dir := filepath.Dir(path)
+	file, err := os.CreateTemp(dir, ".goplaces-photo-*")
+	if err != nil {
+		return "", goplaces.PhotoDownload{}, err
+	}
+	tempPath := file.Name()
+	defer func() {
+		_ = os.Remove(tempPath)
+	}()
+
+	download, err := app.client.PhotoBytes(ctx, request, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", goplaces.PhotoDownload{}, err
+	}
+
+	if filepath.Ext(path) == "" {
+		path += photoExtension(download.ContentType)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return "", goplaces.PhotoDownload{}, err
+	}
+	return path, download, nil
+}
+
+func photoExtension(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	if ext, ok := photoExtensions[mediaType]; ok {
+		return ext
+	}
+	if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+// savedPhoto is the --json summary for --output downloads.
+type savedPhoto struct {
+	Path string `json:"path"`
+	goplaces.PhotoDownload
+}
+
+func writeSavedPhoto(app *App, path string, download goplaces.PhotoDownload) error {
+	if app.json {
+		return writeJSON(app.out, savedPhoto{Path: path, PhotoDownload: download})
+	}
+	label := strings.TrimSpace(download.ContentType)
+	if label == "" {
+		label = "unknown type"
+	}
+	_, err := fmt.Fprintf(app.out, "%s %s (%d bytes, %s)\n", app.color.Dim("Saved:"), path, download.Bytes, label)
+	return err
+}
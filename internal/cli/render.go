@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/steipete/goplaces"
@@ -119,6 +120,22 @@ func renderResolve(color Color, response goplaces.LocationResolveResponse) strin
 	return out.String()
 }
 
+// renderReverse leads with the address, the usual reason to reverse-geocode.
+func renderReverse(color Color, place goplaces.ResolvedLocation) string {
+	var out bytes.Buffer
+	address := place.Address
+	if strings.TrimSpace(address) == "" {
+		address = place.Name
+	}
+	out.WriteString(color.Bold(address))
+	out.WriteString("\n")
+	if place.Name != address {
+		writeLine(&out, color, "Place", place.Name)
+	}
+	writeResolvedLocation(&out, color, place)
+	return out.String()
+}
+
 func renderRoute(color Color, response goplaces.RouteResponse) string {
 	var out bytes.Buffer
 	count := len(response.Waypoints)
@@ -129,7 +146,7 @@ func renderRoute(color Color, response goplaces.RouteResponse) string {
 	out.WriteString("\n")
 
 	for i, waypoint := range response.Waypoints {
-		out.WriteString(color.Bold(fmt.Sprintf("Waypoint %d", i+1)))
+		out.WriteString(color.Bold(fmt.Sprintf("Waypoint %d (km %.1f)", i+1, waypoint.CumulativeDistanceM/1000)))
 		out.WriteString(" ")
 		out.WriteString(color.Dim(fmt.Sprintf("(%.6f, %.6f)", waypoint.Location.Lat, waypoint.Location.Lng)))
 		out.WriteString("\n")
@@ -155,6 +172,81 @@ func renderRoute(color Color, response goplaces.RouteResponse) string {
 	return out.String()
 }
 
+func renderRouteFlat(color Color, places []flatRoutePlace) string {
+	var out bytes.Buffer
+	count := len(places)
+	if count == 0 {
+		return emptyResultsMessage
+	}
+	out.WriteString(color.Bold(fmt.Sprintf("Route places (%d)", count)))
+	out.WriteString("\n")
+
+	for i, place := range places {
+		out.WriteString(fmt.Sprintf("%d. %s\n", i+1, formatTitle(color, place.Name, place.Address)))
+		writePlaceSummary(&out, color, place.PlaceSummary)
+		labels := make([]string, 0, len(place.WaypointIndexes))
+		for _, index := range place.WaypointIndexes {
+			labels = append(labels, fmt.Sprintf("%d", index+1))
+		}
+		writeLine(&out, color, "Waypoints", strings.Join(labels, ", "))
+		if i < count-1 {
+			out.WriteString("\n")
+		}
+	}
+	return out.String()
+}
+
+// renderResultsSummary aggregates ratings, price levels, and open-now state
+// across results into a single footer line.
+func renderResultsSummary(color Color, results []goplaces.PlaceSummary) string {
+	var ratingSum float64
+	rated := 0
+	minPrice, maxPrice := -1, -1
+	open, known := 0, 0
+	for _, place := range results {
+		if place.Rating != nil {
+			ratingSum += *place.Rating
+			rated++
+		}
+		if place.PriceLevel != nil {
+			level := *place.PriceLevel
+			if minPrice < 0 || level < minPrice {
+				minPrice = level
+			}
+			if maxPrice < 0 || level > maxPrice {
+				maxPrice = level
+			}
+		}
+		if place.OpenNow != nil {
+			known++
+			if *place.OpenNow {
+				open++
+			}
+		}
+	}
+
+	parts := make([]string, 0, 3)
+	if rated == 0 {
+		parts = append(parts, "no ratings")
+	} else {
+		parts = append(parts, fmt.Sprintf("avg rating %.1f (%d of %d rated)", ratingSum/float64(rated), rated, len(results)))
+	}
+	switch {
+	case minPrice < 0:
+		parts = append(parts, "no price levels")
+	case minPrice == maxPrice:
+		parts = append(parts, fmt.Sprintf("price $%d", minPrice))
+	default:
+		parts = append(parts, fmt.Sprintf("price $%d-$%d", minPrice, maxPrice))
+	}
+	if known == 0 {
+		parts = append(parts, "open now unknown")
+	} else {
+		parts = append(parts, fmt.Sprintf("%d of %d open now", open, known))
+	}
+	return color.Dim("Summary:") + " " + strings.Join(parts, " · ")
+}
+
 func formatTitle(color Color, name string, address string) string {
 	display := strings.TrimSpace(name)
 	if display == "" {
@@ -188,9 +280,10 @@ func autocompleteSubtitle(suggestion goplaces.AutocompleteSuggestion) string {
 func writePlaceSummary(out *bytes.Buffer, color Color, place goplaces.PlaceSummary) {
 	writeLine(out, color, "ID", place.PlaceID)
 	writeLocation(out, color, place.Location)
-	writeRating(out, color, place.Rating, place.PriceLevel)
+	writeRating(out, color, place.Rating, place.UserRatingCount, formatPrice(place.PriceLevel, place.PriceRange))
 	writeTypes(out, color, place.Types)
 	writeOpenNow(out, color, place.OpenNow)
+	writeBusinessStatus(out, color, place.BusinessStatus)
 }
 
 func writeAutocompleteSuggestion(out *bytes.Buffer, color Color, suggestion goplaces.AutocompleteSuggestion) {
@@ -205,12 +298,27 @@ func writeAutocompleteSuggestion(out *bytes.Buffer, color Color, suggestion gopl
 
 func writePlaceDetails(out *bytes.Buffer, color Color, place goplaces.PlaceDetails) {
 	writeLine(out, color, "ID", place.PlaceID)
+	writeLine(out, color, "Short address", place.ShortAddress)
 	writeLocation(out, color, place.Location)
-	writeRating(out, color, place.Rating, place.PriceLevel)
+	writeRating(out, color, place.Rating, place.UserRatingCount, formatPrice(place.PriceLevel, place.PriceRange))
 	writeTypes(out, color, place.Types)
 	writeOpenNow(out, color, place.OpenNow)
+	writeBusinessStatus(out, color, place.BusinessStatus)
+	if place.UTCOffsetMinutes != nil {
+		writeLine(out, color, "Time zone", formatUTCOffset(*place.UTCOffsetMinutes))
+	}
 	writeLine(out, color, "Phone", place.Phone)
+	writeLine(out, color, "Intl phone", place.InternationalPhone)
 	writeLine(out, color, "Website", place.Website)
+	writeLine(out, color, "Plus code", formatPlusCode(place.PlusCode))
+	writeLine(out, color, "EV charging", formatEVCharging(place.EVChargeOptions))
+	writeLine(out, color, "Services", strings.Join(serviceList(place.Services), ", "))
+	writeLine(out, color, "Amenities", strings.Join(amenityList(place.Amenities), ", "))
+	writeLine(out, color, "Wheelchair access", strings.Join(accessibilityList(place.Accessibility), ", "))
+	writeAddressComponents(out, color, place.AddressComponents)
+	if box := place.Viewport; box != nil {
+		writeLine(out, color, "Viewport", fmt.Sprintf("%.6f, %.6f → %.6f, %.6f", box.SW.Lat, box.SW.Lng, box.NE.Lat, box.NE.Lng))
+	}
 	writePhotos(out, color, place.Photos)
 	writeReviews(out, color, place.Reviews)
 	if len(place.Hours) > 0 {
@@ -230,6 +338,103 @@ func writeResolvedLocation(out *bytes.Buffer, color Color, place goplaces.Resolv
 	writeTypes(out, color, place.Types)
 }
 
+// serviceList names the service options that are explicitly true, in a
+// fixed order; false and unknown options are left out.
+func serviceList(options *goplaces.ServiceOptions) []string {
+	if options == nil {
+		return nil
+	}
+	entries := []struct {
+		value *bool
+		label string
+	}{
+		{options.DineIn, "dine-in"},
+		{options.Takeout, "takeout"},
+		{options.Delivery, "delivery"},
+		{options.CurbsidePickup, "curbside pickup"},
+		{options.Reservable, "reservations"},
+	}
+	var services []string
+	for _, entry := range entries {
+		if entry.value != nil && *entry.value {
+			services = append(services, entry.label)
+		}
+	}
+	return services
+}
+
+func amenityList(amenities *goplaces.Amenities) []string {
+	if amenities == nil {
+		return nil
+	}
+	entries := []struct {
+		value *bool
+		label string
+	}{
+		{amenities.ServesBeer, "beer"},
+		{amenities.ServesWine, "wine"},
+		{amenities.ServesCocktails, "cocktails"},
+		{amenities.ServesBreakfast, "breakfast"},
+		{amenities.ServesBrunch, "brunch"},
+		{amenities.ServesLunch, "lunch"},
+		{amenities.ServesDinner, "dinner"},
+		{amenities.ServesVegetarianFood, "vegetarian food"},
+		{amenities.OutdoorSeating, "outdoor seating"},
+		{amenities.LiveMusic, "live music"},
+		{amenities.MenuForChildren, "kids' menu"},
+		{amenities.AllowsDogs, "dogs allowed"},
+	}
+	var labels []string
+	for _, entry := range entries {
+		if entry.value != nil && *entry.value {
+			labels = append(labels, entry.label)
+		}
+	}
+	return labels
+}
+
+func accessibilityList(options *goplaces.AccessibilityOptions) []string {
+	if options == nil {
+		return nil
+	}
+	entries := []struct {
+		value *bool
+		label string
+	}{
+		{options.WheelchairAccessibleEntrance, "entrance"},
+		{options.WheelchairAccessibleParking, "parking"},
+		{options.WheelchairAccessibleRestroom, "restroom"},
+		{options.WheelchairAccessibleSeating, "seating"},
+	}
+	var accessible []string
+	for _, entry := range entries {
+		if entry.value != nil && *entry.value {
+			accessible = append(accessible, entry.label)
+		}
+	}
+	return accessible
+}
+
+func writeAddressComponents(out *bytes.Buffer, color Color, components []goplaces.AddressComponent) {
+	if len(components) == 0 {
+		return
+	}
+	out.WriteString(color.Dim("Address components:"))
+	out.WriteString("\n")
+	for _, component := range components {
+		out.WriteString("  - ")
+		if len(component.Types) > 0 {
+			out.WriteString(component.Types[0])
+			out.WriteString(": ")
+		}
+		out.WriteString(component.LongText)
+		if component.ShortText != "" && component.ShortText != component.LongText {
+			out.WriteString(" (" + component.ShortText + ")")
+		}
+		out.WriteString("\n")
+	}
+}
+
 func writePhotos(out *bytes.Buffer, color Color, photos []goplaces.Photo) {
 	if len(photos) == 0 {
 		return
@@ -300,20 +505,84 @@ func writeLocation(out *bytes.Buffer, color Color, loc *goplaces.LatLng) {
 	writeLine(out, color, "Location", fmt.Sprintf("%.6f, %.6f", loc.Lat, loc.Lng))
 }
 
-func writeRating(out *bytes.Buffer, color Color, rating *float64, priceLevel *int) {
-	if rating == nil && priceLevel == nil {
+func writeRating(out *bytes.Buffer, color Color, rating *float64, count *int, price string) {
+	if rating == nil && price == "" {
 		return
 	}
 	parts := make([]string, 0, 2)
 	if rating != nil {
-		parts = append(parts, fmt.Sprintf("%.1f", *rating))
+		value := fmt.Sprintf("%.1f", *rating)
+		if count != nil {
+			value += fmt.Sprintf(" (%s)", formatThousands(*count))
+		}
+		parts = append(parts, value)
 	}
-	if priceLevel != nil {
-		parts = append(parts, fmt.Sprintf("$%d", *priceLevel))
+	if price != "" {
+		parts = append(parts, price)
 	}
 	writeLine(out, color, "Rating", strings.Join(parts, " · "))
 }
 
+// currencySymbols covers the currencies whose symbol is unambiguous enough
+// to print bare; others are prefixed with their ISO code.
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"INR": "₹",
+}
+
+// formatPrice prefers the price range (e.g. "$10–20", "$100+") and falls
+// back to the level notation ("$2"). Empty when neither is known.
+func formatPrice(level *int, priceRange *goplaces.PriceRange) string {
+	if priceRange != nil {
+		prefix := priceRange.CurrencyCode + " "
+		if symbol, ok := currencySymbols[priceRange.CurrencyCode]; ok {
+			prefix = symbol
+		} else if priceRange.CurrencyCode == "" {
+			prefix = ""
+		}
+		switch {
+		case priceRange.Start != nil && priceRange.End != nil:
+			return prefix + formatAmount(*priceRange.Start) + "–" + formatAmount(*priceRange.End)
+		case priceRange.Start != nil:
+			return prefix + formatAmount(*priceRange.Start) + "+"
+		case priceRange.End != nil:
+			return "up to " + prefix + formatAmount(*priceRange.End)
+		}
+	}
+	if level != nil {
+		return fmt.Sprintf("$%d", *level)
+	}
+	return ""
+}
+
+// formatAmount drops the fraction from whole amounts: 10 -> "10", 9.5 -> "9.50".
+func formatAmount(amount float64) string {
+	if amount == float64(int64(amount)) {
+		return strconv.FormatInt(int64(amount), 10)
+	}
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// formatThousands renders n with comma separators, e.g. 1203 -> "1,203".
+func formatThousands(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	var out strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out.WriteByte(',')
+		}
+		out.WriteRune(digit)
+	}
+	return sign + out.String()
+}
+
 func writeTypes(out *bytes.Buffer, color Color, types []string) {
 	if len(types) == 0 {
 		return
@@ -333,6 +602,81 @@ func writeOpenNow(out *bytes.Buffer, color Color, openNow *bool) {
 	writeLine(out, color, "Open now", value)
 }
 
+// formatEVCharging summarizes chargers as "3/8 available, up to 150 kW".
+// Availability is left out when no connector type reports it.
+func formatEVCharging(options *goplaces.EVChargeOptions) string {
+	if options == nil {
+		return ""
+	}
+	total := options.ConnectorCount
+	var available, counted int
+	var maxRate float64
+	for _, connector := range options.Connectors {
+		if options.ConnectorCount == 0 {
+			total += connector.Count
+		}
+		if connector.AvailableCount != nil {
+			available += *connector.AvailableCount
+			counted++
+		}
+		if connector.MaxChargeRateKW != nil {
+			maxRate = max(maxRate, *connector.MaxChargeRateKW)
+		}
+	}
+	parts := []string{fmt.Sprintf("%d connectors", total)}
+	if counted > 0 {
+		parts[0] = fmt.Sprintf("%d/%d available", available, total)
+	}
+	if maxRate > 0 {
+		parts = append(parts, "up to "+strconv.FormatFloat(maxRate, 'f', -1, 64)+" kW")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatPlusCode prefers the global code and appends the compound form.
+func formatPlusCode(code *goplaces.PlusCode) string {
+	switch {
+	case code == nil:
+		return ""
+	case code.GlobalCode == "":
+		return code.CompoundCode
+	case code.CompoundCode == "":
+		return code.GlobalCode
+	}
+	return fmt.Sprintf("%s (%s)", code.GlobalCode, code.CompoundCode)
+}
+
+// formatUTCOffset renders minutes from UTC as "UTC+5:30", "UTC-3:30", or "UTC".
+func formatUTCOffset(minutes int) string {
+	if minutes == 0 {
+		return "UTC"
+	}
+	sign := "+"
+	if minutes < 0 {
+		sign = "-"
+		minutes = -minutes
+	}
+	value := fmt.Sprintf("UTC%s%d", sign, minutes/60)
+	if minutes%60 != 0 {
+		value += fmt.Sprintf(":%02d", minutes%60)
+	}
+	return value
+}
+
+// writeBusinessStatus prints OPERATIONAL/CLOSED_* as "operational",
+// "closed temporarily", etc., highlighting closures.
+func writeBusinessStatus(out *bytes.Buffer, color Color, status string) {
+	status = strings.TrimSpace(status)
+	value := strings.ToLower(strings.ReplaceAll(status, "_", " "))
+	switch status {
+	case goplaces.BusinessStatusClosedTemporarily:
+		value = color.Yellow(value)
+	case goplaces.BusinessStatusClosedPermanently:
+		value = color.Red(value)
+	}
+	writeLine(out, color, "Status", value)
+}
+
 func writeLine(out *bytes.Buffer, color Color, label string, value string) {
 	if strings.TrimSpace(value) == "" {
 		return
@@ -107,8 +107,9 @@ func TestRenderRoute(t *testing.T) {
 	response := goplaces.RouteResponse{
 		Waypoints: []goplaces.RouteWaypoint{
 			{
-				Location: goplaces.LatLng{Lat: 1, Lng: 2},
-				Results:  []goplaces.PlaceSummary{{PlaceID: "place-1", Name: "Cafe"}},
+				Location:            goplaces.LatLng{Lat: 1, Lng: 2},
+				CumulativeDistanceM: 42149,
+				Results:             []goplaces.PlaceSummary{{PlaceID: "place-1", Name: "Cafe"}},
 			},
 		},
 	}
@@ -116,8 +117,8 @@ func TestRenderRoute(t *testing.T) {
 	if !strings.Contains(output, "Route waypoints") {
 		t.Fatalf("missing route header")
 	}
-	if !strings.Contains(output, "Waypoint 1") {
-		t.Fatalf("missing waypoint label")
+	if !strings.Contains(output, "Waypoint 1 (km 42.1)") {
+		t.Fatalf("missing waypoint label with distance: %s", output)
 	}
 	if !strings.Contains(output, "Cafe") {
 		t.Fatalf("missing place name")
@@ -154,16 +155,17 @@ func TestRenderDetailsAndResolve(t *testing.T) {
 	open := false
 	level := 0
 	details := goplaces.PlaceDetails{
-		PlaceID:    "place-1",
-		Name:       "Park",
-		Address:    "Central",
-		Rating:     floatPtr(4.2),
-		PriceLevel: &level,
-		Types:      []string{"park"},
-		Phone:      "+1 555",
-		Website:    "https://example.com",
-		Hours:      []string{"Mon: 9-5"},
-		OpenNow:    &open,
+		PlaceID:        "place-1",
+		Name:           "Park",
+		Address:        "Central",
+		Rating:         floatPtr(4.2),
+		PriceLevel:     &level,
+		Types:          []string{"park"},
+		Phone:          "+1 555",
+		Website:        "https://example.com",
+		Hours:          []string{"Mon: 9-5"},
+		OpenNow:        &open,
+		BusinessStatus: goplaces.BusinessStatusClosedTemporarily,
 		Photos: []goplaces.Photo{
 			{Name: "places/place-1/photos/photo-1", WidthPx: 1200, HeightPx: 800},
 		},
@@ -180,6 +182,9 @@ func TestRenderDetailsAndResolve(t *testing.T) {
 	if !strings.Contains(output, "Park") || !strings.Contains(output, "Hours:") {
 		t.Fatalf("unexpected details output: %s", output)
 	}
+	if !strings.Contains(output, "Status: closed temporarily") {
+		t.Fatalf("missing business status: %s", output)
+	}
 	if !strings.Contains(output, "Photos:") {
 		t.Fatalf("missing photos output: %s", output)
 	}
@@ -259,6 +264,241 @@ func TestColorWrap(t *testing.T) {
 	}
 }
 
+func TestRenderDetailsBusinessStatus(t *testing.T) {
+	cases := []struct {
+		status string
+		want   string
+	}{
+		{goplaces.BusinessStatusOperational, "\x1b[2mStatus:\x1b[0m operational\n"},
+		{goplaces.BusinessStatusClosedTemporarily, "\x1b[2mStatus:\x1b[0m \x1b[33mclosed temporarily\x1b[0m\n"},
+		{goplaces.BusinessStatusClosedPermanently, "\x1b[2mStatus:\x1b[0m \x1b[31mclosed permanently\x1b[0m\n"},
+	}
+	for _, tc := range cases {
+		output := renderDetails(NewColor(true), goplaces.PlaceDetails{PlaceID: "place-1", BusinessStatus: tc.status})
+		if !strings.Contains(output, tc.want) {
+			t.Fatalf("status %s: expected %q in %q", tc.status, tc.want, output)
+		}
+	}
+}
+
+func TestFormatUTCOffset(t *testing.T) {
+	cases := map[int]string{
+		0:    "UTC",
+		330:  "UTC+5:30",
+		-210: "UTC-3:30",
+		-300: "UTC-5",
+		60:   "UTC+1",
+		-30:  "UTC-0:30",
+	}
+	for minutes, want := range cases {
+		if got := formatUTCOffset(minutes); got != want {
+			t.Fatalf("formatUTCOffset(%d) = %q, want %q", minutes, got, want)
+		}
+	}
+
+	offset := -210
+	output := renderDetails(NewColor(false), goplaces.PlaceDetails{PlaceID: "place-1", UTCOffsetMinutes: &offset})
+	if !strings.Contains(output, "Time zone: UTC-3:30\n") {
+		t.Fatalf("missing offset line: %s", output)
+	}
+}
+
+func TestRenderDetailsPhones(t *testing.T) {
+	output := renderDetails(NewColor(false), goplaces.PlaceDetails{
+		PlaceID:            "place-1",
+		Phone:              "(555) 010-0199",
+		InternationalPhone: "+1 555-010-0199",
+	})
+	if !strings.Contains(output, "Phone: (555) 010-0199\nIntl phone: +1 555-010-0199\n") {
+		t.Fatalf("missing phone lines: %s", output)
+	}
+	output = renderDetails(NewColor(false), goplaces.PlaceDetails{PlaceID: "place-1", InternationalPhone: "+1 555-010-0199"})
+	if strings.Contains(output, "Phone:") || !strings.Contains(output, "Intl phone: +1 555-010-0199\n") {
+		t.Fatalf("unexpected international-only output: %s", output)
+	}
+}
+
+func TestFormatEVCharging(t *testing.T) {
+	rate, slow := 150.0, 7.2
+	one := 1
+	cases := []struct {
+		options *goplaces.EVChargeOptions
+		want    string
+	}{
+		{nil, ""},
+		{&goplaces.EVChargeOptions{ConnectorCount: 6, Connectors: []goplaces.EVConnector{
+			{Count: 4, MaxChargeRateKW: &rate, AvailableCount: &one},
+			{Count: 2, MaxChargeRateKW: &slow},
+		}}, "1/6 available, up to 150 kW"},
+		{&goplaces.EVChargeOptions{Connectors: []goplaces.EVConnector{{Count: 2, MaxChargeRateKW: &slow}}}, "2 connectors, up to 7.2 kW"},
+		{&goplaces.EVChargeOptions{ConnectorCount: 3}, "3 connectors"},
+	}
+	for _, tc := range cases {
+		if got := formatEVCharging(tc.options); got != tc.want {
+			t.Fatalf("formatEVCharging(%#v) = %q, want %q", tc.options, got, tc.want)
+		}
+	}
+	output := renderDetails(NewColor(false), goplaces.PlaceDetails{PlaceID: "place-1", EVChargeOptions: &goplaces.EVChargeOptions{ConnectorCount: 3}})
+	if !strings.Contains(output, "EV charging: 3 connectors\n") {
+		t.Fatalf("missing ev line: %s", output)
+	}
+}
+
+func TestRenderDetailsPlusCode(t *testing.T) {
+	output := renderDetails(NewColor(false), goplaces.PlaceDetails{
+		PlaceID:  "place-1",
+		PlusCode: &goplaces.PlusCode{GlobalCode: "849VCWC8+R9", CompoundCode: "CWC8+R9 Seattle, WA"},
+	})
+	if !strings.Contains(output, "Plus code: 849VCWC8+R9 (CWC8+R9 Seattle, WA)\n") {
+		t.Fatalf("missing plus code line: %s", output)
+	}
+	if got := formatPlusCode(&goplaces.PlusCode{CompoundCode: "CWC8+R9 Seattle"}); got != "CWC8+R9 Seattle" {
+		t.Fatalf("unexpected compound-only plus code: %q", got)
+	}
+}
+
+func TestRenderDetailsServices(t *testing.T) {
+	yes, no := true, false
+	details := goplaces.PlaceDetails{
+		PlaceID: "place-1",
+		Name:    "Cafe",
+		Services: &goplaces.ServiceOptions{
+			DineIn:     &yes,
+			Takeout:    &no,
+			Delivery:   &yes,
+			Reservable: &yes,
+		},
+	}
+	output := renderDetails(NewColor(false), details)
+	if !strings.Contains(output, "Services: dine-in, delivery, reservations\n") {
+		t.Fatalf("unexpected services line: %s", output)
+	}
+
+	details.Services = &goplaces.ServiceOptions{Takeout: &no}
+	output = renderDetails(NewColor(false), details)
+	if strings.Contains(output, "Services:") {
+		t.Fatalf("expected no services line: %s", output)
+	}
+}
+
+func TestRenderDetailsAccessibility(t *testing.T) {
+	yes, no := true, false
+	output := renderDetails(NewColor(false), goplaces.PlaceDetails{
+		PlaceID: "place-1",
+		Accessibility: &goplaces.AccessibilityOptions{
+			WheelchairAccessibleParking:  &yes,
+			WheelchairAccessibleEntrance: &yes,
+			WheelchairAccessibleRestroom: &no,
+		},
+	})
+	if !strings.Contains(output, "Wheelchair access: entrance, parking\n") {
+		t.Fatalf("unexpected accessibility line: %s", output)
+	}
+}
+
+func TestRenderDetailsAmenities(t *testing.T) {
+	yes, no := true, false
+	output := renderDetails(NewColor(false), goplaces.PlaceDetails{
+		PlaceID: "place-1",
+		Amenities: &goplaces.Amenities{
+			ServesBeer:     &yes,
+			ServesWine:     &no,
+			OutdoorSeating: &yes,
+			AllowsDogs:     &yes,
+		},
+	})
+	if !strings.Contains(output, "Amenities: beer, outdoor seating, dogs allowed\n") {
+		t.Fatalf("unexpected amenities line: %s", output)
+	}
+}
+
 func floatPtr(v float64) *float64 {
 	return &v
 }
+
+func TestRenderRouteFlat(t *testing.T) {
+	output := renderRouteFlat(NewColor(false), []flatRoutePlace{
+		{PlaceSummary: goplaces.PlaceSummary{PlaceID: "a", Name: "Cafe"}, WaypointIndexes: []int{0, 2}},
+	})
+	if !strings.Contains(output, "Route places (1)") || !strings.Contains(output, "Waypoints: 1, 3") {
+		t.Fatalf("unexpected output: %s", output)
+	}
+	if renderRouteFlat(NewColor(false), nil) != emptyResultsMessage {
+		t.Fatalf("expected empty message")
+	}
+}
+
+func TestRenderResultsSummary(t *testing.T) {
+	open := true
+	closed := false
+	two := 2
+	four := 4
+	output := renderResultsSummary(NewColor(false), []goplaces.PlaceSummary{
+		{PlaceID: "a", Rating: floatPtr(4.0), PriceLevel: &two, OpenNow: &open},
+		{PlaceID: "b", Rating: floatPtr(5.0), PriceLevel: &four, OpenNow: &closed},
+		{PlaceID: "c", OpenNow: &open},
+		{PlaceID: "d"},
+	})
+	want := "Summary: avg rating 4.5 (2 of 4 rated) · price $2-$4 · 2 of 3 open now"
+	if output != want {
+		t.Fatalf("unexpected summary:\n%s\nwant:\n%s", output, want)
+	}
+
+	output = renderResultsSummary(NewColor(false), []goplaces.PlaceSummary{{PlaceID: "a"}})
+	if output != "Summary: no ratings · no price levels · open now unknown" {
+		t.Fatalf("unexpected empty summary: %s", output)
+	}
+}
+
+func TestRenderRatingWithCount(t *testing.T) {
+	level := 2
+	count := 1203
+	output := renderSearch(NewColor(false), goplaces.SearchResponse{
+		Results: []goplaces.PlaceSummary{{PlaceID: "abc", Name: "Cafe", Rating: floatPtr(4.5), UserRatingCount: &count, PriceLevel: &level}},
+	})
+	if !strings.Contains(output, "Rating: 4.5 (1,203) · $2") {
+		t.Fatalf("unexpected rating line: %s", output)
+	}
+	for n, want := range map[int]string{0: "0", 999: "999", 1000: "1,000", 1234567: "1,234,567", -4500: "-4,500"} {
+		if got := formatThousands(n); got != want {
+			t.Fatalf("formatThousands(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestFormatPrice(t *testing.T) {
+	level := 2
+	cases := []struct {
+		priceRange *goplaces.PriceRange
+		want       string
+	}{
+		{&goplaces.PriceRange{CurrencyCode: "USD", Start: floatPtr(10), End: floatPtr(20)}, "$10–20"},
+		{&goplaces.PriceRange{CurrencyCode: "EUR", Start: floatPtr(9.5), End: floatPtr(15)}, "€9.50–15"},
+		{&goplaces.PriceRange{CurrencyCode: "CHF", Start: floatPtr(100)}, "CHF 100+"},
+		{&goplaces.PriceRange{CurrencyCode: "USD", End: floatPtr(20)}, "up to $20"},
+		{nil, "$2"},
+	}
+	for _, tc := range cases {
+		if got := formatPrice(&level, tc.priceRange); got != tc.want {
+			t.Fatalf("formatPrice(%+v) = %q, want %q", tc.priceRange, got, tc.want)
+		}
+	}
+	if got := formatPrice(nil, nil); got != "" {
+		t.Fatalf("expected empty price, got %q", got)
+	}
+}
+
+func TestPhotoExtension(t *testing.T) {
+	cases := map[string]string{
+		"image/jpeg":                ".jpg",
+		"image/png; charset=binary": ".png",
+		"image/webp":                ".webp",
+		"":                          "",
+		"application/x-unknown-xyz": "",
+	}
+	for contentType, want := range cases {
+		if got := photoExtension(contentType); got != want {
+			t.Fatalf("photoExtension(%q) = %q, want %q", contentType, got, want)
+		}
+	}
+}
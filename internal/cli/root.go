@@ -14,40 +14,72 @@ type Root struct {
 	Details      DetailsCmd      `cmd:"" help:"Fetch place details by place ID."`
 	Photo        PhotoCmd        `cmd:"" help:"Fetch a photo URL by photo name."`
 	Resolve      ResolveCmd      `cmd:"" help:"Resolve a location string to candidate places."`
+	Reverse      ReverseCmd      `cmd:"" help:"Find the address/place nearest to a coordinate."`
+	Doctor       DoctorCmd       `cmd:"" help:"Diagnose API key, API enablement, and connectivity."`
 }
 
 // GlobalOptions are flags shared by all commands.
 type GlobalOptions struct {
-	APIKey        string        `help:"Google Places API key." env:"GOOGLE_PLACES_API_KEY"`
-	BaseURL       string        `help:"Places API base URL." env:"GOOGLE_PLACES_BASE_URL" default:"https://places.googleapis.com/v1"`
-	RoutesBaseURL string        `help:"Routes API base URL." env:"GOOGLE_ROUTES_BASE_URL" default:"https://routes.googleapis.com"`
-	Timeout       time.Duration `help:"HTTP timeout." default:"10s"`
-	JSON          bool          `help:"Output JSON."`
-	NoColor       bool          `help:"Disable color output."`
-	Verbose       bool          `help:"Verbose logging."`
-	Version       VersionFlag   `name:"version" help:"Print version and exit."`
+	APIKey          string        `help:"Google Places API key." env:"GOOGLE_PLACES_API_KEY"`
+	BaseURL         string        `help:"Places API base URL." env:"GOOGLE_PLACES_BASE_URL" default:"https://places.googleapis.com/v1"`
+	RoutesBaseURL   string        `help:"Routes API base URL." env:"GOOGLE_ROUTES_BASE_URL" default:"https://routes.googleapis.com"`
+	Timeout         time.Duration `help:"Timeout per request and for the whole command (route, --all, retries included)." default:"10s"`
+	JSON            bool          `help:"Output JSON."`
+	NoColor         bool          `help:"Disable color output."`
+	Locale          string        `help:"Default --language and --region for every command, e.g. en-US or pt-BR."`
+	Verbose         bool          `help:"Log each API request (method, URL, field mask, status, duration) to stderr."`
+	Quiet           bool          `help:"Suppress informational stderr notices such as next_page_token."`
+	DryRun          bool          `help:"Print each request's method, URL, field mask, and JSON body instead of sending it." name:"dry-run"`
+	Timing          bool          `help:"Print per-request latency (and a total) to stderr."`
+	Retries         int           `help:"Retry 429/5xx responses this many times per request (0-10)."`
+	MaxTotalRetries int           `help:"Cap retries across all requests in this run (0 = no cap)." name:"max-total-retries"`
+	RPS             float64       `help:"Max API requests per second (0 = unlimited)." name:"rps"`
+	CacheTTL        time.Duration `help:"Reuse identical details responses within this window (ETag-revalidated after; 0 = off)." name:"cache-ttl" default:"0"`
+	NoCache         bool          `help:"Disable the response cache." name:"no-cache"`
+	CacheSearches   bool          `help:"Also cache identical text searches (off by default)." name:"cache-searches"`
+	Version         VersionFlag   `name:"version" help:"Print version and exit."`
 }
 
 // SearchCmd runs text search queries.
 type SearchCmd struct {
-	Query      string   `arg:"" name:"query" help:"Search text."`
-	Limit      int      `help:"Max results (1-20)." default:"10"`
-	PageToken  string   `help:"Page token for pagination."`
-	Language   string   `help:"BCP-47 language code (e.g. en, en-US)."`
-	Region     string   `help:"CLDR region code (e.g. US, DE)."`
-	Keyword    string   `help:"Keyword to append to the query."`
-	Type       []string `help:"Place type filter (includedType). Repeatable."`
-	OpenNow    *bool    `help:"Return only currently open places."`
-	MinRating  *float64 `help:"Minimum rating (0-5)."`
-	PriceLevel []int    `help:"Price levels 0-4. Repeatable."`
-	Lat        *float64 `help:"Latitude for location bias."`
-	Lng        *float64 `help:"Longitude for location bias."`
-	RadiusM    *float64 `help:"Radius in meters for location bias."`
+	Query         string   `arg:"" name:"query" help:"Search text."`
+	Limit         int      `help:"Max results (1-20)." default:"10"`
+	PageToken     string   `help:"Page token for pagination."`
+	PageSize      int      `help:"Results per API call (1-20); pages are followed until --limit."`
+	All           bool     `help:"Follow page tokens and merge every page (deduped; --limit applies per page)."`
+	MaxPages      int      `help:"Max pages to fetch with --all (0 = no cap)." default:"5"`
+	Language      string   `help:"BCP-47 language code (e.g. en, en-US)."`
+	Region        string   `help:"CLDR region code (e.g. US, DE)."`
+	Keyword       string   `help:"Keyword to append to the query."`
+	Type          []string `help:"Place type filter (includedType; a single value, use nearby for several)."`
+	StrictType    bool     `help:"Only return places whose type exactly matches --type." name:"strict-type"`
+	OpenNow       *bool    `help:"Return only currently open places."`
+	MinRating     *float64 `help:"Minimum rating (0-5)."`
+	PriceLevel    []int    `help:"Price levels 0-4. Repeatable."`
+	Lat           *float64 `help:"Latitude for location bias."`
+	Lng           *float64 `help:"Longitude for location bias."`
+	RadiusM       *float64 `help:"Radius in meters for location bias (default 5000 when --lat/--lng are set)."`
+	BBox          string   `name:"bbox" help:"Rectangle bias: minLng,minLat,maxLng,maxLat (instead of --lat/--lng; use --bbox=... for negative values)."`
+	Rank          string   `help:"Server-side ranking: relevance or distance (distance needs --lat/--lng)."`
+	EchoRequest   bool     `help:"Wrap JSON output as {request, results} for reproducibility."`
+	Slim          bool     `help:"JSON with only place_id, name, lat, lng, rating per place (implies --json)."`
+	IncludeClosed bool     `help:"Keep temporarily/permanently closed places (hidden by default)."`
+	Summary       bool     `help:"Print an aggregate rating/price/open-now footer (human output)."`
+	Sort          string   `help:"Client-side order: relevance (API order), rating, rating-count, distance (from --lat/--lng)." enum:"none,relevance,rating,rating-count,distance" default:"none"`
+	LocalOnly     bool     `help:"Drop well-known chains by name (heuristic)."`
+	ChainList     string   `help:"File of chain names, one per line (implies --local-only)." type:"path"`
+	Fields        []string `help:"Request only these Place fields (comma-separated, e.g. displayName,rating; id is always included)."`
+	FieldsMinimal bool     `help:"Request only place IDs, the cheapest field mask (pairs with --ids-only)." name:"fields-minimal"`
+	Raw           bool     `help:"Print the API's JSON response unmodified (one page; no mapping, filtering, or sorting)."`
+	ListOutput    `embed:""`
+	MaskOutput    `embed:""`
 }
 
 // AutocompleteCmd runs autocomplete queries.
 type AutocompleteCmd struct {
-	Input        string   `arg:"" name:"input" help:"Autocomplete input text."`
+	Input        string   `arg:"" name:"input" optional:"" help:"Autocomplete input text (omit with --inputs-file)."`
+	InputsFile   string   `help:"Autocomplete every input in this file, one per line ('-' for stdin); prints NDJSON." name:"inputs-file" type:"path"`
+	Concurrency  int      `help:"Parallel requests with --inputs-file (1-20)." default:"4"`
 	Limit        int      `help:"Max suggestions (1-20)." default:"5"`
 	SessionToken string   `help:"Session token for billing consistency."`
 	Language     string   `help:"BCP-47 language code (e.g. en, en-US)."`
@@ -55,27 +87,61 @@ type AutocompleteCmd struct {
 	Lat          *float64 `help:"Latitude for location bias."`
 	Lng          *float64 `help:"Longitude for location bias."`
 	RadiusM      *float64 `help:"Radius in meters for location bias."`
+	BBox         string   `name:"bbox" help:"Rectangle bias: minLng,minLat,maxLng,maxLat (instead of --lat/--lng; use --bbox=... for negative values)."`
+	PrimaryType  []string `help:"Match only places whose primary type is one of these, or a collection like (cities). Repeatable, up to 5." aliases:"included-primary-type"`
+	InputOffset  *int     `help:"Cursor position in the input (Unicode characters; default: end of input)." name:"input-offset"`
+	Origin       string   `help:"Show each place's distance from this point: lat,lng (use --origin=... for negative values)."`
+	MaskOutput   `embed:""`
 }
 
 // NearbyCmd runs nearby searches.
 type NearbyCmd struct {
-	Limit       int      `help:"Max results (1-20)." default:"10"`
-	Type        []string `help:"Included place types. Repeatable."`
-	ExcludeType []string `help:"Excluded place types. Repeatable."`
-	Language    string   `help:"BCP-47 language code (e.g. en, en-US)."`
-	Region      string   `help:"CLDR region code (e.g. US, DE)."`
-	Lat         *float64 `help:"Latitude for location restriction."`
-	Lng         *float64 `help:"Longitude for location restriction."`
-	RadiusM     *float64 `help:"Radius in meters for location restriction."`
+	Limit              int      `help:"Max results (1-20; Google caps nearby at 20 with no pagination)." default:"10"`
+	Type               []string `help:"Included place types. Repeatable."`
+	ExcludeType        []string `help:"Excluded place types. Repeatable."`
+	PrimaryType        []string `help:"Match only places whose primary type is one of these. Repeatable." aliases:"included-primary-type"`
+	ExcludePrimaryType []string `help:"Drop places whose primary type is one of these. Repeatable." name:"excluded-primary-type" aliases:"exclude-primary-type"`
+	ListTypes          bool     `help:"Print the place types --type/--exclude-type accept and exit." name:"list-types"`
+	MinRating          *float64 `help:"Minimum rating (0-5), applied client-side."`
+	RankBy             string   `help:"Rank by popularity or distance (API default when empty)." enum:",popularity,distance" default:""`
+	Language           string   `help:"BCP-47 language code (e.g. en, en-US)."`
+	Region             string   `help:"CLDR region code (e.g. US, DE)."`
+	Lat                *float64 `help:"Latitude for location restriction."`
+	Lng                *float64 `help:"Longitude for location restriction."`
+	RadiusM            *float64 `help:"Radius in meters for location restriction."`
+	EchoRequest        bool     `help:"Wrap JSON output as {request, results} for reproducibility."`
+	IncludeClosed      bool     `help:"Keep temporarily/permanently closed places (hidden by default)."`
+	Grid               bool     `help:"Tile the radius into smaller searches to exceed the 20-result cap (one billed request per tile)."`
+	TileM              float64  `help:"Tile spacing in meters for --grid." default:"500"`
+	Summary            bool     `help:"Print an aggregate rating/price/open-now footer (human output)."`
+	Sort               string   `help:"Client-side order: relevance (API order), rating, rating-count, distance (from --lat/--lng)." enum:"none,relevance,rating,rating-count,distance" default:"none"`
+	LocalOnly          bool     `help:"Drop well-known chains by name (heuristic)."`
+	ChainList          string   `help:"File of chain names, one per line (implies --local-only)." type:"path"`
+	Raw                bool     `help:"Print the API's JSON response unmodified (no mapping, filtering, or sorting)."`
+	ListOutput         `embed:""`
+	MaskOutput         `embed:""`
 }
 
 // DetailsCmd fetches place details.
 type DetailsCmd struct {
-	PlaceID  string `arg:"" name:"place_id" help:"Place ID."`
-	Language string `help:"BCP-47 language code (e.g. en, en-US)."`
-	Region   string `help:"CLDR region code (e.g. US, DE)."`
-	Reviews  bool   `help:"Include reviews in the response."`
-	Photos   bool   `help:"Include photos in the response."`
+	PlaceID           string   `arg:"" name:"place_id" optional:"" help:"Place ID (omit with --ids-file)."`
+	IDsFile           string   `help:"Look up every place ID in this file, one per line ('-' for stdin)." name:"ids-file" type:"path"`
+	Concurrency       int      `help:"Parallel lookups with --ids-file (1-20)." default:"4"`
+	MaxErrors         int      `help:"Abort --ids-file once more than this many lookups fail (0 = never)." name:"max-errors"`
+	Language          string   `help:"BCP-47 language code (e.g. en, en-US)."`
+	Region            string   `help:"CLDR region code (e.g. US, DE)."`
+	Reviews           bool     `help:"Include reviews in the response."`
+	Photos            bool     `help:"Include photos in the response."`
+	Services          bool     `help:"Include service options (dine-in, takeout, delivery, ...)."`
+	AddressComponents bool     `help:"Include structured address components." name:"address-components"`
+	Viewport          bool     `help:"Include the map viewport (bounding box)."`
+	ShortAddress      bool     `help:"Include the short formatted address." name:"short-address"`
+	EV                bool     `help:"Include EV charging options (connectors, charge rates)." name:"ev"`
+	Accessibility     bool     `help:"Include wheelchair accessibility options."`
+	Amenities         bool     `help:"Include amenities (beer, wine, breakfast, outdoor seating, dogs, ...)."`
+	Fields            []string `help:"Replace the base field mask (comma-separated Place fields, e.g. displayName,websiteUri; id is always included)."`
+	Raw               bool     `help:"Print the API's JSON response unmodified (--fields may then name any Place field)."`
+	MaskOutput        `embed:""`
 }
 
 // PhotoCmd fetches a photo URL.
@@ -83,6 +149,7 @@ type PhotoCmd struct {
 	Name        string `arg:"" name:"photo_name" help:"Photo resource name (places/.../photos/...)."`
 	MaxWidthPx  int    `help:"Max width in pixels." name:"max-width"`
 	MaxHeightPx int    `help:"Max height in pixels." name:"max-height"`
+	Output      string `help:"Download the image to this path ('-' for stdout); extension added from content type if missing." short:"o" type:"path"`
 }
 
 // ResolveCmd resolves a location string into candidates.
@@ -91,4 +158,13 @@ type ResolveCmd struct {
 	Limit        int    `help:"Max results (1-10)." default:"5"`
 	Language     string `help:"BCP-47 language code (e.g. en, en-US)."`
 	Region       string `help:"CLDR region code (e.g. US, DE)."`
+	ListOutput   `embed:""`
+}
+
+// ReverseCmd finds the place nearest to a coordinate.
+type ReverseCmd struct {
+	Lat      float64 `help:"Latitude." required:""`
+	Lng      float64 `help:"Longitude (use --lng=-122.3 for negative values)." required:""`
+	Language string  `help:"BCP-47 language code (e.g. en, en-US)."`
+	Region   string  `help:"CLDR region code (e.g. US, DE)."`
 }
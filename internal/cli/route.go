@@ -3,42 +3,109 @@ package cli
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/steipete/goplaces"
 )
 
 // RouteCmd searches along a route between two locations.
 type RouteCmd struct {
-	Query        string  `arg:"" name:"query" help:"Search text."`
-	From         string  `help:"Origin location (address or place name)."`
-	To           string  `help:"Destination location (address or place name)."`
-	Mode         string  `help:"Travel mode: DRIVE, WALK, BICYCLE, TWO_WHEELER, TRANSIT." default:"DRIVE"`
-	RadiusM      float64 `help:"Search radius in meters." default:"1000"`
-	MaxWaypoints int     `help:"Max sampled waypoints along the route." default:"5"`
-	Limit        int     `help:"Max results per waypoint (1-20)." default:"5"`
-	Language     string  `help:"BCP-47 language code (e.g. en, en-US)."`
-	Region       string  `help:"CLDR region code (e.g. US, DE)."`
+	Query         string   `arg:"" name:"query" help:"Search text."`
+	From          string   `help:"Origin location (address, place name, or placeId:<id>)."`
+	To            string   `help:"Destination location (address, place name, or placeId:<id>)."`
+	Via           []string `help:"Stop between --from and --to, in order (same forms; up to 25). Repeatable."`
+	Mode          string   `help:"Travel mode: DRIVE, WALK, BICYCLE, TWO_WHEELER, TRANSIT." default:"DRIVE"`
+	RadiusM       float64  `help:"Search radius in meters." default:"1000"`
+	MaxWaypoints  int      `help:"Max sampled waypoints along the route." default:"5"`
+	Concurrency   int      `help:"Parallel waypoint searches (1-20)." default:"4"`
+	SampleSeed    int64    `help:"Jitter interior waypoints deterministically with this seed (0 = evenly spaced)."`
+	Limit         int      `help:"Max results per waypoint (1-20)." default:"5"`
+	Language      string   `help:"BCP-47 language code (e.g. en, en-US)."`
+	Region        string   `help:"CLDR region code (e.g. US, DE)."`
+	Type          []string `help:"Place type filter (includedType; a single value)."`
+	MinRating     *float64 `help:"Minimum rating (0-5) for waypoint results."`
+	OpenNow       *bool    `help:"Return only currently open places."`
+	Flatten       bool     `help:"Merge places across waypoints into one deduped list."`
+	DepartAt      string   `help:"Departure time for DRIVE (traffic-aware), TWO_WHEELER, or TRANSIT routes: RFC3339 (e.g. 2026-05-01T08:30:00+02:00) or now." name:"depart-at"`
+	TransitMode   []string `help:"TRANSIT vehicles to allow: BUS, SUBWAY, TRAIN, LIGHT_RAIL, RAIL. Repeatable." name:"transit-mode"`
+	TransitPref   string   `help:"TRANSIT routing preference: less-walking or fewer-transfers." enum:",less-walking,fewer-transfers" default:"" name:"transit-pref"`
+	RoutingPref   string   `help:"Traffic routing for DRIVE/TWO_WHEELER with --depart-at: traffic-aware (default) or traffic-aware-optimal (slower)." enum:",traffic-aware,traffic-aware-optimal" default:"" name:"routing-preference"`
+	AvoidTolls    bool     `help:"Avoid toll roads where possible (DRIVE/TWO_WHEELER)." name:"avoid-tolls"`
+	AvoidHighways bool     `help:"Avoid highways where possible (DRIVE/TWO_WHEELER)." name:"avoid-highways"`
+	AvoidFerries  bool     `help:"Avoid ferries where possible (DRIVE/TWO_WHEELER)." name:"avoid-ferries"`
+}
+
+// flatRoutePlace is a place found along a route plus the waypoints it appeared under.
+type flatRoutePlace struct {
+	goplaces.PlaceSummary
+	WaypointIndexes []int `json:"waypoint_indexes"`
 }
 
 // Run executes the route command.
 func (c *RouteCmd) Run(app *App) error {
+	language, region := normalizeLocale(app, c.Language, c.Region)
 	request := goplaces.RouteRequest{
-		Query:        c.Query,
-		From:         c.From,
-		To:           c.To,
-		Mode:         c.Mode,
-		RadiusM:      c.RadiusM,
-		MaxWaypoints: c.MaxWaypoints,
-		Limit:        c.Limit,
-		Language:     c.Language,
-		Region:       c.Region,
-	}
-
-	response, err := app.client.Route(context.Background(), request)
+		Query:         c.Query,
+		From:          c.From,
+		To:            c.To,
+		Via:           c.Via,
+		Mode:          c.Mode,
+		RadiusM:       c.RadiusM,
+		MaxWaypoints:  c.MaxWaypoints,
+		Concurrency:   c.Concurrency,
+		SampleSeed:    c.SampleSeed,
+		Limit:         c.Limit,
+		Language:      language,
+		Region:        region,
+		MinRating:     c.MinRating,
+		OpenNow:       c.OpenNow,
+		Types:         c.Type,
+		AvoidTolls:    c.AvoidTolls,
+		AvoidHighways: c.AvoidHighways,
+		AvoidFerries:  c.AvoidFerries,
+	}
+	request.RoutingPreference = strings.ReplaceAll(c.RoutingPref, "-", "_")
+	switch {
+	case strings.EqualFold(strings.TrimSpace(c.DepartAt), "now"):
+		request.DepartNow = true
+	case c.DepartAt != "":
+		departAt, err := parseDepartAt(c.DepartAt)
+		if err != nil {
+			return err
+		}
+		request.DepartureTime = departAt
+	case request.RoutingPreference != "":
+		return goplaces.ValidationError{Field: "routing_preference", Message: "requires --depart-at"}
+	}
+	if len(c.TransitMode) > 0 || c.TransitPref != "" {
+		request.TransitPreferences = &goplaces.TransitPreferences{
+			RoutingPreference:  strings.ReplaceAll(c.TransitPref, "-", "_"),
+			AllowedTravelModes: c.TransitMode,
+		}
+	}
+
+	if app.dryRun {
+		return app.describe(app.client.DescribeRoute(request))
+	}
+
+	// One deadline covers the route call and every waypoint search.
+	ctx, cancel := app.deadline(context.Background())
+	defer cancel()
+	response, err := app.client.Route(ctx, request)
 	if err != nil {
 		return err
 	}
 
+	if c.Flatten {
+		places := flattenRoute(response)
+		if app.json {
+			return writeJSON(app.out, places)
+		}
+		_, err = fmt.Fprintln(app.out, renderRouteFlat(app.color, places))
+		return err
+	}
+
 	if app.json {
 		return writeJSON(app.out, response)
 	}
@@ -46,3 +113,34 @@ func (c *RouteCmd) Run(app *App) error {
 	_, err = fmt.Fprintln(app.out, renderRoute(app.color, response))
 	return err
 }
+
+// parseDepartAt reads an RFC3339 --depart-at; "now" is handled by the
+// caller as DepartNow.
+func parseDepartAt(value string) (time.Time, error) {
+	departAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, goplaces.ValidationError{Field: "depart_at", Message: "must be RFC3339 (e.g. 2026-05-01T08:30:00+02:00) or now"}
+	}
+	return departAt, nil
+}
+
+// flattenRoute dedupes places by ID in first-seen order, recording every
+// (0-based) waypoint index each place appeared under.
+func flattenRoute(response goplaces.RouteResponse) []flatRoutePlace {
+	places := make([]flatRoutePlace, 0)
+	positions := make(map[string]int)
+	for index, waypoint := range response.Waypoints {
+		for _, place := range waypoint.Results {
+			if position, ok := positions[place.PlaceID]; ok {
+				indexes := places[position].WaypointIndexes
+				if indexes[len(indexes)-1] != index {
+					places[position].WaypointIndexes = append(indexes, index)
+				}
+				continue
+			}
+			positions[place.PlaceID] = len(places)
+			places = append(places, flatRoutePlace{PlaceSummary: place, WaypointIndexes: []int{index}})
+		}
+	}
+	return places
+}
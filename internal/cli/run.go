@@ -1,24 +1,79 @@
 package cli
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/steipete/goplaces"
 )
 
+// defaultSearchBiasRadiusM is used when search gets --lat/--lng without --radius-m.
+const defaultSearchBiasRadiusM = 5000
+
 // App wires CLI output and API access.
 type App struct {
 	client *goplaces.Client
+	in     io.Reader
 	out    io.Writer
 	err    io.Writer
 	json   bool
 	color  Color
+	// timeout is --timeout, applied per command as well as per request.
+	timeout time.Duration
+	// quiet is --quiet: informational stderr notices are dropped.
+	quiet bool
+	// language and region come from --locale and apply where
+	// --language/--region are not given.
+	language string
+	region   string
+	// dryRun is --dry-run: commands print the request they would send.
+	dryRun bool
+}
+
+// notef prints an informational notice to stderr unless --quiet is set.
+// Warnings and errors bypass it.
+func (app *App) notef(format string, args ...any) {
+	if app.quiet {
+		return
+	}
+	_, _ = fmt.Fprintf(app.err, format+"\n", args...)
+}
+
+// notePageToken prints the next page token to stderr, where JSON output
+// leaves room for it, unless --quiet is set.
+func (app *App) notePageToken(token string) {
+	if token == "" {
+		return
+	}
+	app.notef("next_page_token: %s", token)
+}
+
+// describe prints a --dry-run request description as pretty JSON.
+func (app *App) describe(description goplaces.RequestDescription, err error) error {
+	if err != nil {
+		return err
+	}
+	return writeJSON(app.out, description)
+}
+
+// deadline bounds everything a command does by --timeout, so retries and
+// multi-request commands (route, --all, --grid) cannot outlive it.
+func (app *App) deadline(parent context.Context) (context.Context, context.CancelFunc) {
+	if app.timeout <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, app.timeout)
 }
 
 // Run executes the CLI with the provided arguments.
@@ -63,28 +118,69 @@ func Run(args []string, stdout io.Writer, stderr io.Writer) int {
 		_, _ = fmt.Fprintln(stderr, err)
 		return 2
 	}
+	if root.Global.Retries < 0 || root.Global.Retries > goplaces.MaxRetriesLimit {
+		_, _ = fmt.Fprintln(stderr, goplaces.ValidationError{
+			Field:   "retries",
+			Message: fmt.Sprintf("must be 0-%d", goplaces.MaxRetriesLimit),
+		})
+		return 2
+	}
 	if root.Global.JSON {
 		// JSON output should never include ANSI escapes.
 		root.Global.NoColor = true
 	}
 
-	client := goplaces.NewClient(goplaces.Options{
-		APIKey:        root.Global.APIKey,
-		BaseURL:       root.Global.BaseURL,
-		RoutesBaseURL: root.Global.RoutesBaseURL,
-		Timeout:       root.Global.Timeout,
-	})
+	options := goplaces.Options{
+		APIKey:          root.Global.APIKey,
+		BaseURL:         root.Global.BaseURL,
+		RoutesBaseURL:   root.Global.RoutesBaseURL,
+		Timeout:         root.Global.Timeout,
+		MaxRetries:      root.Global.Retries,
+		MaxTotalRetries: root.Global.MaxTotalRetries,
+		RateLimit:       root.Global.RPS,
+		CacheTTL:        root.Global.CacheTTL,
+		CacheSearches:   root.Global.CacheSearches,
+	}
+	if root.Global.NoCache {
+		options.CacheTTL = 0
+	}
+	options.Warn = func(message string) {
+		_, _ = fmt.Fprintln(stderr, "warning:", message)
+	}
+	if root.Global.Verbose {
+		options.Logger = slog.New(slog.NewTextHandler(stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+	var timer *requestTimer
+	if root.Global.Timing {
+		timer = &requestTimer{out: stderr, json: root.Global.JSON}
+		options.RequestHook = timer.record
+	}
+	client := goplaces.NewClient(options)
 
 	app := &App{
-		client: client,
-		out:    stdout,
-		err:    stderr,
-		json:   root.Global.JSON,
-		color:  NewColor(colorEnabled(root.Global.NoColor)),
+		client:  client,
+		in:      os.Stdin,
+		out:     stdout,
+		err:     stderr,
+		json:    root.Global.JSON,
+		color:   NewColor(colorEnabled(root.Global.NoColor)),
+		timeout: root.Global.Timeout,
+		quiet:   root.Global.Quiet,
+		dryRun:  root.Global.DryRun,
+	}
+	if root.Global.Locale != "" {
+		app.language, app.region, err = parseLocale(root.Global.Locale)
+		if err != nil {
+			return handleError(stderr, err)
+		}
 	}
 
 	ctx.Bind(app)
-	if err := ctx.Run(); err != nil {
+	err = ctx.Run()
+	if timer != nil {
+		timer.finish()
+	}
+	if err != nil {
 		return handleError(stderr, err)
 	}
 
@@ -115,14 +211,105 @@ func parseWithExit(parser *kong.Kong, args []string, exitCode *int) (ctx *kong.C
 	return ctx, exited, err
 }
 
+// locationError names the missing parts of a --lat/--lng/--radius-m trio,
+// e.g. "lng and radius_m required when lat is set".
+func locationError(field string, lat, lng, radius *float64, needRadius bool) error {
+	var missing, set []string
+	for _, part := range []struct {
+		name     string
+		value    *float64
+		required bool
+	}{
+		{"lat", lat, true},
+		{"lng", lng, true},
+		{"radius_m", radius, needRadius},
+	} {
+		switch {
+		case part.value != nil:
+			set = append(set, part.name)
+		case part.required:
+			missing = append(missing, part.name)
+		}
+	}
+
+	message := joinWords(missing) + " required"
+	if len(set) > 0 {
+		verb := "is"
+		if len(set) > 1 {
+			verb = "are"
+		}
+		message += fmt.Sprintf(" when %s %s set", joinWords(set), verb)
+	}
+	return goplaces.ValidationError{Field: field, Message: message}
+}
+
+// bboxBias parses --bbox "minLng,minLat,maxLng,maxLat" (GeoJSON order) into
+// a rectangular bias. It cannot be combined with --lat/--lng/--radius-m.
+func bboxBias(value string, lat, lng, radius *float64) (*goplaces.LocationBias, error) {
+	if lat != nil || lng != nil || radius != nil {
+		return nil, goplaces.ValidationError{Field: "bbox", Message: "use either --bbox or --lat/--lng/--radius-m"}
+	}
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return nil, goplaces.ValidationError{Field: "bbox", Message: "expected minLng,minLat,maxLng,maxLat"}
+	}
+	coords := make([]float64, len(parts))
+	for i, part := range parts {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, goplaces.ValidationError{Field: "bbox", Message: fmt.Sprintf("invalid number %q", strings.TrimSpace(part))}
+		}
+		coords[i] = parsed
+	}
+	return &goplaces.LocationBias{Rectangle: &goplaces.BoundingBox{
+		SW: goplaces.LatLng{Lat: coords[1], Lng: coords[0]},
+		NE: goplaces.LatLng{Lat: coords[3], Lng: coords[2]},
+	}}, nil
+}
+
+// parseLatLng parses a "lat,lng" flag value.
+func parseLatLng(field, value string) (goplaces.LatLng, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return goplaces.LatLng{}, goplaces.ValidationError{Field: field, Message: "expected lat,lng"}
+	}
+	var coords [2]float64
+	for i, part := range parts {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return goplaces.LatLng{}, goplaces.ValidationError{Field: field, Message: fmt.Sprintf("invalid number %q", strings.TrimSpace(part))}
+		}
+		coords[i] = parsed
+	}
+	return goplaces.LatLng{Lat: coords[0], Lng: coords[1]}, nil
+}
+
+// joinWords joins values as "a", "a and b", or "a, b, and c".
+func joinWords(values []string) string {
+	switch len(values) {
+	case 0:
+		return ""
+	case 1:
+		return values[0]
+	case 2:
+		return values[0] + " and " + values[1]
+	}
+	return strings.Join(values[:len(values)-1], ", ") + ", and " + values[len(values)-1]
+}
+
 // Run executes the search command.
 func (c *SearchCmd) Run(app *App) error {
+	language, region := normalizeLocale(app, c.Language, c.Region)
 	request := goplaces.SearchRequest{
-		Query:     c.Query,
-		Limit:     c.Limit,
-		PageToken: c.PageToken,
-		Language:  c.Language,
-		Region:    c.Region,
+		Query:               c.Query,
+		Limit:               c.Limit,
+		PageToken:           c.PageToken,
+		PageSize:            c.PageSize,
+		Language:            language,
+		Region:              region,
+		RankPreference:      c.Rank,
+		StrictTypeFiltering: c.StrictType,
+		Fields:              c.Fields,
 	}
 
 	filters := goplaces.Filters{}
@@ -151,49 +338,171 @@ func (c *SearchCmd) Run(app *App) error {
 		request.Filters = &filters
 	}
 
-	if c.Lat != nil || c.Lng != nil || c.RadiusM != nil {
-		if c.Lat == nil || c.Lng == nil || c.RadiusM == nil {
-			return goplaces.ValidationError{Field: "location_bias", Message: "lat, lng, radius required"}
+	if c.BBox != "" {
+		bias, err := bboxBias(c.BBox, c.Lat, c.Lng, c.RadiusM)
+		if err != nil {
+			return err
+		}
+		request.LocationBias = bias
+	} else if c.Lat != nil || c.Lng != nil || c.RadiusM != nil {
+		if c.Lat == nil || c.Lng == nil {
+			return locationError("location_bias", c.Lat, c.Lng, c.RadiusM, false)
+		}
+		radius := float64(defaultSearchBiasRadiusM)
+		if c.RadiusM != nil {
+			radius = *c.RadiusM
 		}
 		request.LocationBias = &goplaces.LocationBias{
 			Lat:     *c.Lat,
 			Lng:     *c.Lng,
-			RadiusM: *c.RadiusM,
+			RadiusM: radius,
 		}
 	}
 
-	response, err := app.client.Search(context.Background(), request)
+	if err := c.check(app); err != nil {
+		return err
+	}
+	if c.Slim && c.tabular() {
+		return goplaces.ValidationError{Field: "slim", Message: "use either --slim or --format " + c.Format}
+	}
+	if c.FieldsMinimal {
+		if len(c.Fields) > 0 {
+			return goplaces.ValidationError{Field: "fields_minimal", Message: "use either --fields-minimal or --fields"}
+		}
+		request.Fields = []string{"id"}
+	}
+	if c.Slim && c.IDsOnly {
+		return goplaces.ValidationError{Field: "slim", Message: "use either --slim or --ids-only"}
+	}
+	if c.Slim && c.GeoLocation {
+		return goplaces.ValidationError{Field: "slim", Message: "use either --slim or --geo-location"}
+	}
+	if c.Raw {
+		if err := c.checkRaw(); err != nil {
+			return err
+		}
+		if c.All || c.Slim {
+			return goplaces.ValidationError{Field: "raw", Message: "--raw cannot be combined with --all or --slim"}
+		}
+	}
+	// Reject --sort distance without a center before spending a request.
+	if err := sortPlaces(nil, c.Sort, biasCenter(request.LocationBias)); err != nil {
+		return err
+	}
+	chains, err := localOnlyFilter(c.LocalOnly, c.ChainList)
 	if err != nil {
 		return err
 	}
+	c.reportMask(app, request.FieldMask())
+	if app.dryRun {
+		return app.describe(app.client.DescribeSearch(request))
+	}
+	if c.Raw {
+		ctx, cancel := app.deadline(context.Background())
+		defer cancel()
+		payload, err := app.client.SearchRaw(ctx, request)
+		if err != nil {
+			return err
+		}
+		return writeRaw(app.out, payload)
+	}
 
-	if app.json {
-		if err := writeJSON(app.out, response.Results); err != nil {
+	ctx, cancel := app.deadline(context.Background())
+	defer cancel()
+	if c.All {
+		response, err := app.client.SearchAll(ctx, request, c.MaxPages)
+		if err != nil && len(response.Results) == 0 {
 			return err
 		}
-		if response.NextPageToken != "" {
-			_, _ = fmt.Fprintln(app.err, "next_page_token:", response.NextPageToken)
+		// A failed --all still prints the pages gathered before the error.
+		if writeErr := c.writeResults(app, request, response, chains); writeErr != nil {
+			return writeErr
 		}
+		return err
+	}
+
+	response, err := app.client.Search(ctx, request)
+	if err != nil {
+		return err
+	}
+	return c.writeResults(app, request, response, chains)
+}
+
+func (c *SearchCmd) writeResults(
+	app *App,
+	request goplaces.SearchRequest,
+	response goplaces.SearchResponse,
+	chains *chainMatcher,
+) error {
+	if !c.IncludeClosed {
+		response.Results = dropClosed(response.Results)
+	}
+	response.Results = chains.filter(response.Results)
+	if err := sortPlaces(response.Results, c.Sort, biasCenter(request.LocationBias)); err != nil {
+		return err
+	}
+	results, err := firstResult(c.First, response.Results)
+	if err != nil {
+		return err
+	}
+	response.Results = results
+
+	if c.IDsOnly {
+		return c.writeIDs(app, response.Results)
+	}
+	if c.tabular() {
+		return c.write(app.out, response.Results)
+	}
+	if app.json || c.Slim || c.GeoLocation {
+		results := c.placesJSON(response.Results)
+		if c.Slim {
+			results = listJSON(c.First, slimPlaces(response.Results))
+		}
+		if err := writeResultsJSON(app.out, c.EchoRequest, request, results); err != nil {
+			return err
+		}
+		app.notePageToken(response.NextPageToken)
 		return nil
 	}
 
+	noteNameLanguage(app, request.Language, summaryNameLanguages(response.Results)...)
 	_, err = fmt.Fprintln(app.out, renderSearch(app.color, response))
+	if err != nil || !c.Summary || len(response.Results) == 0 {
+		return err
+	}
+	_, err = fmt.Fprintln(app.out, "\n"+renderResultsSummary(app.color, response.Results))
 	return err
 }
 
 // Run executes the autocomplete command.
 func (c *AutocompleteCmd) Run(app *App) error {
+	language, region := normalizeLocale(app, c.Language, c.Region)
 	request := goplaces.AutocompleteRequest{
-		Input:        c.Input,
-		Limit:        c.Limit,
-		SessionToken: c.SessionToken,
-		Language:     c.Language,
-		Region:       c.Region,
+		Input:                c.Input,
+		Limit:                c.Limit,
+		SessionToken:         c.SessionToken,
+		Language:             language,
+		Region:               region,
+		IncludedPrimaryTypes: c.PrimaryType,
+		InputOffset:          c.InputOffset,
+	}
+	if c.Origin != "" {
+		origin, err := parseLatLng("origin", c.Origin)
+		if err != nil {
+			return err
+		}
+		request.Origin = &origin
 	}
 
-	if c.Lat != nil || c.Lng != nil || c.RadiusM != nil {
+	if c.BBox != "" {
+		bias, err := bboxBias(c.BBox, c.Lat, c.Lng, c.RadiusM)
+		if err != nil {
+			return err
+		}
+		request.LocationBias = bias
+	} else if c.Lat != nil || c.Lng != nil || c.RadiusM != nil {
 		if c.Lat == nil || c.Lng == nil || c.RadiusM == nil {
-			return goplaces.ValidationError{Field: "location_bias", Message: "lat, lng, radius required"}
+			return locationError("location_bias", c.Lat, c.Lng, c.RadiusM, true)
 		}
 		request.LocationBias = &goplaces.LocationBias{
 			Lat:     *c.Lat,
@@ -202,7 +511,23 @@ func (c *AutocompleteCmd) Run(app *App) error {
 		}
 	}
 
-	response, err := app.client.Autocomplete(context.Background(), request)
+	c.reportMask(app, request.FieldMask())
+	if c.InputsFile != "" {
+		if c.Input != "" {
+			return goplaces.ValidationError{Field: "input", Message: "use either an input or --inputs-file"}
+		}
+		return c.runBatch(app, request)
+	}
+	if c.Input == "" {
+		return goplaces.ValidationError{Field: "input", Message: "required (or use --inputs-file)"}
+	}
+	if app.dryRun {
+		return app.describe(app.client.DescribeAutocomplete(request))
+	}
+
+	ctx, cancel := app.deadline(context.Background())
+	defer cancel()
+	response, err := app.client.Autocomplete(ctx, request)
 	if err != nil {
 		return err
 	}
@@ -217,8 +542,12 @@ func (c *AutocompleteCmd) Run(app *App) error {
 
 // Run executes the nearby command.
 func (c *NearbyCmd) Run(app *App) error {
+	if c.ListTypes {
+		return writeLines(app, goplaces.PlaceTypes())
+	}
+	language, region := normalizeLocale(app, c.Language, c.Region)
 	if c.Lat == nil || c.Lng == nil || c.RadiusM == nil {
-		return goplaces.ValidationError{Field: "location_restriction", Message: "lat, lng, radius required"}
+		return locationError("location_restriction", c.Lat, c.Lng, c.RadiusM, true)
 	}
 
 	request := goplaces.NearbySearchRequest{
@@ -227,41 +556,137 @@ func (c *NearbyCmd) Run(app *App) error {
 			Lng:     *c.Lng,
 			RadiusM: *c.RadiusM,
 		},
-		Limit:         c.Limit,
-		IncludedTypes: c.Type,
-		ExcludedTypes: c.ExcludeType,
-		Language:      c.Language,
-		Region:        c.Region,
+		Limit:                c.Limit,
+		IncludedTypes:        c.Type,
+		ExcludedTypes:        c.ExcludeType,
+		IncludedPrimaryTypes: c.PrimaryType,
+		ExcludedPrimaryTypes: c.ExcludePrimaryType,
+		Language:             language,
+		Region:               region,
+		MinRating:            c.MinRating,
+		RankPreference:       c.RankBy,
+	}
+
+	if err := c.check(app); err != nil {
+		return err
+	}
+	if c.Raw {
+		if err := c.checkRaw(); err != nil {
+			return err
+		}
+		if c.Grid {
+			return goplaces.ValidationError{Field: "raw", Message: "--raw cannot be combined with --grid"}
+		}
+	}
+	chains, err := localOnlyFilter(c.LocalOnly, c.ChainList)
+	if err != nil {
+		return err
+	}
+	c.reportMask(app, request.FieldMask())
+	if app.dryRun {
+		return app.describe(app.client.DescribeNearbySearch(request))
+	}
+	if c.Raw {
+		ctx, cancel := app.deadline(context.Background())
+		defer cancel()
+		payload, err := app.client.NearbySearchRaw(ctx, request)
+		if err != nil {
+			return err
+		}
+		return writeRaw(app.out, payload)
 	}
 
-	response, err := app.client.NearbySearch(context.Background(), request)
+	ctx, cancel := app.deadline(context.Background())
+	defer cancel()
+	var response goplaces.NearbySearchResponse
+	if c.Grid {
+		center := goplaces.LatLng{Lat: *c.Lat, Lng: *c.Lng}
+		response, err = app.client.NearbyGrid(ctx, center, *c.RadiusM, c.TileM, request)
+	} else {
+		response, err = app.client.NearbySearch(ctx, request)
+	}
 	if err != nil {
 		return err
 	}
+	if !c.IncludeClosed {
+		response.Results = dropClosed(response.Results)
+	}
+	response.Results = chains.filter(response.Results)
+	if err := sortPlaces(response.Results, c.Sort, biasCenter(request.LocationRestriction)); err != nil {
+		return err
+	}
+	if response.Results, err = firstResult(c.First, response.Results); err != nil {
+		return err
+	}
 
-	if app.json {
-		if err := writeJSON(app.out, response.Results); err != nil {
+	if c.IDsOnly {
+		return c.writeIDs(app, response.Results)
+	}
+	if c.tabular() {
+		return c.write(app.out, response.Results)
+	}
+	if app.json || c.GeoLocation {
+		if err := writeResultsJSON(app.out, c.EchoRequest, request, c.placesJSON(response.Results)); err != nil {
 			return err
 		}
-		if response.NextPageToken != "" {
-			_, _ = fmt.Fprintln(app.err, "next_page_token:", response.NextPageToken)
-		}
+		app.notePageToken(response.NextPageToken)
 		return nil
 	}
 
+	noteNameLanguage(app, request.Language, summaryNameLanguages(response.Results)...)
 	_, err = fmt.Fprintln(app.out, renderNearby(app.color, response))
+	if err != nil || !c.Summary || len(response.Results) == 0 {
+		return err
+	}
+	_, err = fmt.Fprintln(app.out, "\n"+renderResultsSummary(app.color, response.Results))
 	return err
 }
 
 // Run executes the details command.
 func (c *DetailsCmd) Run(app *App) error {
-	response, err := app.client.DetailsWithOptions(context.Background(), goplaces.DetailsRequest{
-		PlaceID:        c.PlaceID,
-		Language:       c.Language,
-		Region:         c.Region,
-		IncludeReviews: c.Reviews,
-		IncludePhotos:  c.Photos,
-	})
+	language, region := normalizeLocale(app, c.Language, c.Region)
+	request := goplaces.DetailsRequest{
+		PlaceID:                  c.PlaceID,
+		Language:                 language,
+		Region:                   region,
+		IncludeReviews:           c.Reviews,
+		IncludePhotos:            c.Photos,
+		IncludeServices:          c.Services,
+		IncludeAddressComponents: c.AddressComponents,
+		IncludeViewport:          c.Viewport,
+		IncludeShortAddress:      c.ShortAddress,
+		IncludeEV:                c.EV,
+		IncludeAccessibility:     c.Accessibility,
+		IncludeAmenities:         c.Amenities,
+		Fields:                   c.Fields,
+	}
+	c.reportMask(app, request.FieldMask())
+	if c.IDsFile != "" {
+		if c.PlaceID != "" {
+			return goplaces.ValidationError{Field: "place_id", Message: "use either a place ID or --ids-file"}
+		}
+		if c.Raw {
+			return goplaces.ValidationError{Field: "raw", Message: "--raw cannot be combined with --ids-file"}
+		}
+		return c.runBatch(app, request)
+	}
+	if c.PlaceID == "" {
+		return goplaces.ValidationError{Field: "place_id", Message: "required (or use --ids-file)"}
+	}
+	if app.dryRun {
+		return app.describe(app.client.DescribeDetails(request))
+	}
+
+	ctx, cancel := app.deadline(context.Background())
+	defer cancel()
+	if c.Raw {
+		payload, err := app.client.DetailsRaw(ctx, request)
+		if err != nil {
+			return err
+		}
+		return writeRaw(app.out, payload)
+	}
+	response, err := app.client.DetailsWithOptions(ctx, request)
 	if err != nil {
 		return err
 	}
@@ -270,17 +695,38 @@ func (c *DetailsCmd) Run(app *App) error {
 		return writeJSON(app.out, response)
 	}
 
+	noteNameLanguage(app, language, response.NameLanguage)
 	_, err = fmt.Fprintln(app.out, renderDetails(app.color, response))
 	return err
 }
 
 // Run executes the photo command.
 func (c *PhotoCmd) Run(app *App) error {
-	response, err := app.client.PhotoMedia(context.Background(), goplaces.PhotoMediaRequest{
+	request := goplaces.PhotoMediaRequest{
 		Name:        c.Name,
 		MaxWidthPx:  c.MaxWidthPx,
 		MaxHeightPx: c.MaxHeightPx,
-	})
+	}
+	if app.dryRun {
+		return app.describe(app.client.DescribePhotoMedia(request))
+	}
+
+	ctx, cancel := app.deadline(context.Background())
+	defer cancel()
+	switch c.Output {
+	case "":
+	case "-":
+		_, err := app.client.PhotoBytes(ctx, request, app.out)
+		return err
+	default:
+		path, download, err := downloadPhoto(ctx, app, request, c.Output)
+		if err != nil {
+			return err
+		}
+		return writeSavedPhoto(app, path, download)
+	}
+
+	response, err := app.client.PhotoMedia(ctx, request)
 	if err != nil {
 		return err
 	}
@@ -295,26 +741,73 @@ func (c *PhotoCmd) Run(app *App) error {
 
 // Run executes the resolve command.
 func (c *ResolveCmd) Run(app *App) error {
+	language, region := normalizeLocale(app, c.Language, c.Region)
 	request := goplaces.LocationResolveRequest{
 		LocationText: c.LocationText,
 		Limit:        c.Limit,
-		Language:     c.Language,
-		Region:       c.Region,
+		Language:     language,
+		Region:       region,
+	}
+
+	if err := c.check(app); err != nil {
+		return err
+	}
+	if app.dryRun {
+		return app.describe(app.client.DescribeResolve(request))
 	}
 
-	response, err := app.client.Resolve(context.Background(), request)
+	ctx, cancel := app.deadline(context.Background())
+	defer cancel()
+	response, err := app.client.Resolve(ctx, request)
 	if err != nil {
 		return err
 	}
+	if response.Results, err = firstResult(c.First, response.Results); err != nil {
+		return err
+	}
 
-	if app.json {
-		return writeJSON(app.out, response.Results)
+	if c.IDsOnly {
+		return c.writeIDs(app, resolvedPlaces(response.Results))
+	}
+	if c.tabular() {
+		return c.write(app.out, resolvedPlaces(response.Results))
+	}
+	if app.json || c.GeoLocation {
+		return writeJSON(app.out, c.resolvedJSON(response.Results))
 	}
 
 	_, err = fmt.Fprintln(app.out, renderResolve(app.color, response))
 	return err
 }
 
+// Run executes the reverse command.
+func (c *ReverseCmd) Run(app *App) error {
+	language, region := normalizeLocale(app, c.Language, c.Region)
+	request := goplaces.ReverseRequest{
+		Lat:      c.Lat,
+		Lng:      c.Lng,
+		Language: language,
+		Region:   region,
+	}
+	if app.dryRun {
+		return app.describe(app.client.DescribeReverse(request))
+	}
+
+	ctx, cancel := app.deadline(context.Background())
+	defer cancel()
+	place, err := app.client.ReverseWithOptions(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	if app.json {
+		return writeJSON(app.out, place)
+	}
+
+	_, err = fmt.Fprintln(app.out, renderReverse(app.color, place))
+	return err
+}
+
 func writeJSON(writer io.Writer, value any) error {
 	payload, err := json.MarshalIndent(value, "", "  ")
 	if err != nil {
@@ -324,6 +817,86 @@ func writeJSON(writer io.Writer, value any) error {
 	return err
 }
 
+// writeRaw prints an API body as received, ending it with a newline.
+func writeRaw(writer io.Writer, payload []byte) error {
+	if !bytes.HasSuffix(payload, []byte("\n")) {
+		payload = append(payload, '\n')
+	}
+	_, err := writer.Write(payload)
+	return err
+}
+
+// writeCSV writes RFC 4180 rows (quoted as needed) for spreadsheets.
+func writeCSV(writer io.Writer, rows [][]string) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.WriteAll(rows); err != nil {
+		return err
+	}
+	return csvWriter.Error()
+}
+
+// dropClosed hides places Google reports as temporarily or permanently closed.
+// Places without a business status are kept.
+func dropClosed(results []goplaces.PlaceSummary) []goplaces.PlaceSummary {
+	kept := results[:0:0]
+	for _, place := range results {
+		switch place.BusinessStatus {
+		case goplaces.BusinessStatusClosedTemporarily, goplaces.BusinessStatusClosedPermanently:
+			continue
+		}
+		kept = append(kept, place)
+	}
+	return kept
+}
+
+const (
+	sortRating      = "rating"
+	sortRatingCount = "rating-count"
+	sortDistance    = "distance"
+)
+
+// sortPlaces reorders results in place for --sort. Relevance (and none)
+// keep the API order; distance needs a center.
+func sortPlaces(results []goplaces.PlaceSummary, mode string, center *goplaces.LatLng) error {
+	switch mode {
+	case sortRating:
+		goplaces.SortByRating(results)
+	case sortRatingCount:
+		goplaces.SortByRatingCount(results)
+	case sortDistance:
+		if center == nil {
+			return goplaces.ValidationError{Field: "sort", Message: "distance requires --lat/--lng"}
+		}
+		goplaces.SortByDistance(results, *center)
+	}
+	return nil
+}
+
+// biasCenter is the point distances are measured from: the circle center,
+// or the middle of a rectangle.
+func biasCenter(bias *goplaces.LocationBias) *goplaces.LatLng {
+	if bias == nil {
+		return nil
+	}
+	if box := bias.Rectangle; box != nil {
+		return &goplaces.LatLng{Lat: (box.SW.Lat + box.NE.Lat) / 2, Lng: (box.SW.Lng + box.NE.Lng) / 2}
+	}
+	return &goplaces.LatLng{Lat: bias.Lat, Lng: bias.Lng}
+}
+
+// echoedResults pairs results with the request that produced them.
+type echoedResults struct {
+	Request any `json:"request"`
+	Results any `json:"results"`
+}
+
+func writeResultsJSON(writer io.Writer, echo bool, request any, results any) error {
+	if echo {
+		return writeJSON(writer, echoedResults{Request: request, Results: results})
+	}
+	return writeJSON(writer, results)
+}
+
 func handleError(writer io.Writer, err error) int {
 	if err == nil {
 		return 0
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/steipete/goplaces"
+	"golang.org/x/term"
+)
+
+const (
+	defaultTableWidth = 80
+	tablePadding      = 2
+	maxTableName      = 40
+	maxTableTypes     = 30
+	// minTableAddress keeps some address visible on narrow terminals; the
+	// line wraps instead.
+	minTableAddress = 10
+)
+
+var tableColumns = []string{"#", "Name", "Rating", "Price", "Types", "Address"}
+
+// writeTable renders places as aligned columns for --format table, cutting
+// long cells so each row fits the terminal width.
+func writeTable(out io.Writer, places []goplaces.PlaceSummary, header bool) error {
+	rows := make([][]string, 0, len(places)+1)
+	if header {
+		rows = append(rows, tableColumns)
+	}
+	for i, place := range places {
+		row := []string{
+			strconv.Itoa(i + 1),
+			clipCell(place.Name, maxTableName),
+			"",
+			"",
+			clipCell(strings.Join(place.Types, ", "), maxTableTypes),
+			place.Address,
+		}
+		if place.Rating != nil {
+			row[2] = fmt.Sprintf("%.1f", *place.Rating)
+		}
+		row[3] = formatPrice(place.PriceLevel, place.PriceRange)
+		rows = append(rows, row)
+	}
+
+	// Every column but the last (Address) is padded to its widest cell.
+	used := 0
+	for col := range len(tableColumns) - 1 {
+		widest := 0
+		for _, row := range rows {
+			widest = max(widest, utf8.RuneCountInString(row[col]))
+		}
+		used += widest + tablePadding
+	}
+	addressWidth := max(terminalWidth(out)-used, minTableAddress)
+	last := len(tableColumns) - 1
+
+	writer := tabwriter.NewWriter(out, 0, 0, tablePadding, ' ', 0)
+	for _, row := range rows {
+		row[last] = clipCell(row[last], addressWidth)
+		if _, err := fmt.Fprintln(writer, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// clipCell shortens value to at most width runes, marking the cut with "…".
+// Tabs and newlines are flattened so they cannot break the alignment.
+func clipCell(value string, width int) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if utf8.RuneCountInString(value) <= width {
+		return value
+	}
+	runes := []rune(value)
+	return strings.TrimSpace(string(runes[:width-1])) + "…"
+}
+
+// terminalWidth is the column count of out when it is a terminal, else 80.
+func terminalWidth(out io.Writer) int {
+	file, ok := out.(*os.File)
+	if !ok || !term.IsTerminal(int(file.Fd())) {
+		return defaultTableWidth
+	}
+	width, _, err := term.GetSize(int(file.Fd()))
+	if err != nil || width <= 0 {
+		return defaultTableWidth
+	}
+	return width
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/steipete/goplaces"
+)
+
+// requestTimer prints per-request latency to stderr for --timing.
+type requestTimer struct {
+	mu    sync.Mutex
+	out   io.Writer
+	json  bool
+	count int
+	total time.Duration
+}
+
+type timingLine struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Status int    `json:"status,omitempty"`
+	Ms     int64  `json:"ms"`
+}
+
+func (t *requestTimer) record(info goplaces.RequestInfo) {
+	path := info.URL
+	if parsed, err := url.Parse(info.URL); err == nil {
+		path = parsed.Path
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.count++
+	t.total += info.Duration
+	line := timingLine{Method: info.Method, Path: path, Status: info.StatusCode, Ms: info.Duration.Milliseconds()}
+	if t.json {
+		payload, _ := json.Marshal(line)
+		_, _ = fmt.Fprintf(t.out, "%s\n", payload)
+		return
+	}
+	_, _ = fmt.Fprintf(t.out, "timing: %s %s %dms\n", line.Method, line.Path, line.Ms)
+}
+
+// finish prints the total for commands that issued more than one request.
+func (t *requestTimer) finish() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.count < 2 {
+		return
+	}
+	ms := t.total.Milliseconds()
+	if t.json {
+		_, _ = fmt.Fprintf(t.out, "{\"requests\":%d,\"total_ms\":%d}\n", t.count, ms)
+		return
+	}
+	_, _ = fmt.Fprintf(t.out, "timing: total %d requests %dms\n", t.count, ms)
+}
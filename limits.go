@@ -7,6 +7,7 @@ const (
 	maxResolveLimit          = 10
 	defaultAutocompleteLimit = 5
 	maxAutocompleteLimit     = 20
+	maxAutocompleteTypes     = 5
 	defaultNearbyLimit       = 10
 	maxNearbyLimit           = 20
 )
@@ -0,0 +1,47 @@
+package goplaces
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// redacted replaces secret header values in logs.
+const redacted = "REDACTED"
+
+// logRequest records one HTTP round trip on the Options.Logger at debug
+// level. The API key header is redacted; bodies are never logged.
+func (c *Client) logRequest(
+	ctx context.Context,
+	request *http.Request,
+	fieldMask string,
+	status int,
+	duration time.Duration,
+	err error,
+) {
+	if c.logger == nil || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", request.Method),
+		slog.String("url", request.URL.String()),
+		slog.String("field_mask", fieldMask),
+		slog.Int("status", status),
+		slog.Duration("duration", duration),
+		slog.Any("headers", redactHeaders(request.Header)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "goplaces request", attrs...)
+}
+
+// redactHeaders copies header with the API key value masked.
+func redactHeaders(header http.Header) http.Header {
+	clean := header.Clone()
+	if clean.Get(apiKeyHeader) != "" {
+		clean.Set(apiKeyHeader, redacted)
+	}
+	return clean
+}
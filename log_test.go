@@ -0,0 +1,58 @@
+package goplaces
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLoggerRecordsRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := NewClient(Options{
+		APIKey:  "secret-key",
+		BaseURL: server.URL,
+		Logger:  slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+	if _, err := client.Search(context.Background(), SearchRequest{Query: "cafe"}); err != nil {
+		t.Fatalf("search error: %v", err)
+	}
+
+	line := logs.String()
+	for _, want := range []string{"method=POST", "url=" + server.URL + "/places:searchText", "field_mask=places.id,", "status=200", "duration=", redacted} {
+		if !strings.Contains(line, want) {
+			t.Fatalf("log missing %q: %s", want, line)
+		}
+	}
+	if strings.Contains(line, "secret-key") {
+		t.Fatalf("API key leaked into log: %s", line)
+	}
+}
+
+func TestLoggerSkipsAboveDebug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := NewClient(Options{
+		APIKey:  "secret-key",
+		BaseURL: server.URL,
+		Logger:  slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+	if _, err := client.Search(context.Background(), SearchRequest{Query: "cafe"}); err != nil {
+		t.Fatalf("search error: %v", err)
+	}
+	if logs.Len() != 0 {
+		t.Fatalf("expected no info-level logs, got %s", logs.String())
+	}
+}
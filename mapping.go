@@ -1,6 +1,9 @@
 package goplaces
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
 
 func mapReviews(reviews []reviewPayload) []Review {
 	if len(reviews) == 0 {
@@ -40,6 +43,88 @@ func mapPhotos(photos []photoPayload) []Photo {
 	return mapped
 }
 
+func mapAddressComponents(components []addressComponentPayload) []AddressComponent {
+	if len(components) == 0 {
+		return nil
+	}
+	mapped := make([]AddressComponent, 0, len(components))
+	for _, component := range components {
+		mapped = append(mapped, AddressComponent(component))
+	}
+	return mapped
+}
+
+func mapViewport(viewport *viewportPayload) *BoundingBox {
+	if viewport == nil || viewport.Low == nil || viewport.High == nil {
+		return nil
+	}
+	return &BoundingBox{
+		SW: LatLng{Lat: viewport.Low.Latitude, Lng: viewport.Low.Longitude},
+		NE: LatLng{Lat: viewport.High.Latitude, Lng: viewport.High.Longitude},
+	}
+}
+
+func mapEVChargeOptions(options *evChargeOptionsPayload) *EVChargeOptions {
+	if options == nil || (options.ConnectorCount == 0 && len(options.ConnectorAggregation) == 0) {
+		return nil
+	}
+	mapped := &EVChargeOptions{ConnectorCount: options.ConnectorCount}
+	for _, connector := range options.ConnectorAggregation {
+		mapped.Connectors = append(mapped.Connectors, EVConnector(connector))
+	}
+	return mapped
+}
+
+func mapPlusCode(code *plusCodePayload) *PlusCode {
+	if code == nil || (code.GlobalCode == "" && code.CompoundCode == "") {
+		return nil
+	}
+	return &PlusCode{GlobalCode: code.GlobalCode, CompoundCode: code.CompoundCode}
+}
+
+func mapServiceOptions(place placeItem) *ServiceOptions {
+	options := ServiceOptions{
+		DineIn:         place.DineIn,
+		Takeout:        place.Takeout,
+		Delivery:       place.Delivery,
+		CurbsidePickup: place.CurbsidePickup,
+		Reservable:     place.Reservable,
+	}
+	if options == (ServiceOptions{}) {
+		return nil
+	}
+	return &options
+}
+
+func mapAmenities(place placeItem) *Amenities {
+	amenities := Amenities{
+		ServesBeer:           place.ServesBeer,
+		ServesWine:           place.ServesWine,
+		ServesCocktails:      place.ServesCocktails,
+		ServesBreakfast:      place.ServesBreakfast,
+		ServesBrunch:         place.ServesBrunch,
+		ServesLunch:          place.ServesLunch,
+		ServesDinner:         place.ServesDinner,
+		ServesVegetarianFood: place.ServesVegetarianFood,
+		OutdoorSeating:       place.OutdoorSeating,
+		LiveMusic:            place.LiveMusic,
+		MenuForChildren:      place.MenuForChildren,
+		AllowsDogs:           place.AllowsDogs,
+	}
+	if amenities == (Amenities{}) {
+		return nil
+	}
+	return &amenities
+}
+
+func mapAccessibilityOptions(options *accessibilityOptionsPayload) *AccessibilityOptions {
+	if options == nil || *options == (accessibilityOptionsPayload{}) {
+		return nil
+	}
+	mapped := AccessibilityOptions(*options)
+	return &mapped
+}
+
 func mapLocalizedText(text *localizedTextPayload) *LocalizedText {
 	if text == nil {
 		return nil
@@ -109,6 +194,13 @@ func displayName(name *displayNamePayload) string {
 	return name.Text
 }
 
+func displayNameLanguage(name *displayNamePayload) string {
+	if name == nil {
+		return ""
+	}
+	return name.LanguageCode
+}
+
 func openNow(hours *openingHours) *bool {
 	if hours == nil {
 		return nil
@@ -123,6 +215,25 @@ func weekdayDescriptions(hours *openingHours) []string {
 	return hours.WeekdayDescriptions
 }
 
+func openingPeriods(hours *openingHours) []Period {
+	if hours == nil || len(hours.Periods) == 0 {
+		return nil
+	}
+	periods := make([]Period, 0, len(hours.Periods))
+	for _, period := range hours.Periods {
+		if period.Open == nil {
+			continue
+		}
+		mapped := Period{Open: TimeOfWeek(*period.Open)}
+		if period.Close != nil {
+			closing := TimeOfWeek(*period.Close)
+			mapped.Close = &closing
+		}
+		periods = append(periods, mapped)
+	}
+	return periods
+}
+
 func mapPriceLevel(value string) *int {
 	if value == "" {
 		return nil
@@ -132,3 +243,38 @@ func mapPriceLevel(value string) *int {
 	}
 	return nil
 }
+
+// mapPriceRange returns nil unless at least one bound parses.
+func mapPriceRange(payload *priceRangePayload) *PriceRange {
+	if payload == nil {
+		return nil
+	}
+	start, startCurrency := moneyAmount(payload.StartPrice)
+	end, endCurrency := moneyAmount(payload.EndPrice)
+	if start == nil && end == nil {
+		return nil
+	}
+	currency := startCurrency
+	if currency == "" {
+		currency = endCurrency
+	}
+	return &PriceRange{CurrencyCode: currency, Start: start, End: end}
+}
+
+// moneyAmount converts units+nanos to a decimal amount; nil when money is
+// absent or its units are malformed.
+func moneyAmount(money *moneyPayload) (*float64, string) {
+	if money == nil {
+		return nil, ""
+	}
+	units := int64(0)
+	if money.Units != "" {
+		parsed, err := strconv.ParseInt(money.Units, 10, 64)
+		if err != nil {
+			return nil, ""
+		}
+		units = parsed
+	}
+	amount := float64(units) + float64(money.Nanos)/1e9
+	return &amount, money.CurrencyCode
+}
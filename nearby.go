@@ -5,10 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 )
 
-const nearbyFieldMask = "places.id,places.displayName,places.formattedAddress,places.location,places.rating,places.priceLevel,places.types,places.currentOpeningHours"
+const nearbyFieldMask = "places.id,places.displayName,places.formattedAddress,places.location,places.rating,places.userRatingCount,places.priceLevel,places.priceRange,places.types,places.currentOpeningHours,places.businessStatus"
 
 // NearbySearch performs a nearby search around a location restriction.
 func (c *Client) NearbySearch(ctx context.Context, req NearbySearchRequest) (NearbySearchResponse, error) {
@@ -17,8 +18,44 @@ func (c *Client) NearbySearch(ctx context.Context, req NearbySearchRequest) (Nea
 		return NearbySearchResponse{}, err
 	}
 
+	payload, err := c.nearbyPayload(ctx, req)
+	if err != nil {
+		return NearbySearchResponse{}, err
+	}
+
+	var response searchResponse
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return NearbySearchResponse{}, fmt.Errorf("goplaces: decode nearby response: %w", err)
+	}
+
+	results := make([]PlaceSummary, 0, len(response.Places))
+	for _, place := range response.Places {
+		summary := mapPlaceSummary(place)
+		if !meetsMinRating(summary, req.MinRating) {
+			continue
+		}
+		results = append(results, summary)
+	}
+
+	return NearbySearchResponse{Results: results, NextPageToken: response.NextPageToken}, nil
+}
+
+func (c *Client) nearbyPayload(ctx context.Context, req NearbySearchRequest) ([]byte, error) {
+	language, region, err := c.locale(req.Language, req.Region)
+	if err != nil {
+		return nil, err
+	}
+	req.Language, req.Region = language, region
+	endpoint, err := c.buildURL("/places:searchNearby", nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.doClampedRequest(ctx, http.MethodPost, endpoint, buildNearbyBody(req), nearbyFieldMask, "maxResultCount", maxNearbyLimit)
+}
+
+func buildNearbyBody(req NearbySearchRequest) map[string]any {
 	body := map[string]any{
-		"locationRestriction": circlePayload(req.LocationRestriction),
+		"locationRestriction": locationBiasPayload(req.LocationRestriction),
 		"maxResultCount":      req.Limit,
 	}
 	if strings.TrimSpace(req.Language) != "" {
@@ -33,33 +70,23 @@ func (c *Client) NearbySearch(ctx context.Context, req NearbySearchRequest) (Nea
 	if len(req.ExcludedTypes) > 0 {
 		body["excludedTypes"] = req.ExcludedTypes
 	}
-
-	endpoint, err := c.buildURL("/places:searchNearby", nil)
-	if err != nil {
-		return NearbySearchResponse{}, err
-	}
-	payload, err := c.doRequest(ctx, http.MethodPost, endpoint, body, nearbyFieldMask)
-	if err != nil {
-		return NearbySearchResponse{}, err
+	if len(req.IncludedPrimaryTypes) > 0 {
+		body["includedPrimaryTypes"] = req.IncludedPrimaryTypes
 	}
-
-	var response searchResponse
-	if err := json.Unmarshal(payload, &response); err != nil {
-		return NearbySearchResponse{}, fmt.Errorf("goplaces: decode nearby response: %w", err)
+	if len(req.ExcludedPrimaryTypes) > 0 {
+		body["excludedPrimaryTypes"] = req.ExcludedPrimaryTypes
 	}
-
-	results := make([]PlaceSummary, 0, len(response.Places))
-	for _, place := range response.Places {
-		results = append(results, mapPlaceSummary(place))
+	if req.RankPreference != "" {
+		body["rankPreference"] = req.RankPreference
 	}
-
-	return NearbySearchResponse{Results: results, NextPageToken: response.NextPageToken}, nil
+	return body
 }
 
 func applyNearbyDefaults(req NearbySearchRequest) NearbySearchRequest {
 	if req.Limit == 0 {
 		req.Limit = defaultNearbyLimit
 	}
+	req.RankPreference = strings.ToUpper(strings.TrimSpace(req.RankPreference))
 	return req
 }
 
@@ -67,11 +94,58 @@ func validateNearbyRequest(req NearbySearchRequest) error {
 	if req.LocationRestriction == nil {
 		return ValidationError{Field: "location_restriction", Message: "required"}
 	}
+	if req.LocationRestriction.Rectangle != nil {
+		return ValidationError{Field: "location_restriction", Message: "nearby search supports circles only"}
+	}
 	if err := validateLocationBias(req.LocationRestriction); err != nil {
 		return err
 	}
-	if req.Limit < 1 || req.Limit > maxNearbyLimit {
+	if req.Limit > maxNearbyLimit {
+		// Google caps nearby at 20 and offers no pagination past that.
+		return ValidationError{Field: "limit", Message: fmt.Sprintf(
+			"must be 1-%d: nearby search returns at most %d results and cannot paginate past the cap; "+
+				"use text search with a location bias, or split the area into smaller radii",
+			maxNearbyLimit, maxNearbyLimit,
+		)}
+	}
+	if req.Limit < 1 {
 		return ValidationError{Field: "limit", Message: fmt.Sprintf("must be 1-%d", maxNearbyLimit)}
 	}
+	if req.MinRating != nil && (*req.MinRating < 0 || *req.MinRating > 5) {
+		return ValidationError{Field: "min_rating", Message: "must be 0-5"}
+	}
+	switch req.RankPreference {
+	case "", RankPreferencePopularity, RankPreferenceDistance:
+	default:
+		return ValidationError{Field: "rank_preference", Message: "must be POPULARITY or DISTANCE"}
+	}
+	if err := validatePlaceTypes("included_types", req.IncludedTypes); err != nil {
+		return err
+	}
+	if err := validatePlaceTypes("excluded_types", req.ExcludedTypes); err != nil {
+		return err
+	}
+	for _, excluded := range req.ExcludedTypes {
+		if slices.Contains(req.IncludedTypes, excluded) {
+			return ValidationError{Field: "excluded_types", Message: fmt.Sprintf("%q is also an included type", excluded)}
+		}
+	}
+	for _, excluded := range req.ExcludedPrimaryTypes {
+		if slices.Contains(req.IncludedPrimaryTypes, excluded) {
+			return ValidationError{
+				Field:   "excluded_primary_types",
+				Message: fmt.Sprintf("%q is also an included primary type", excluded),
+			}
+		}
+	}
 	return nil
 }
+
+// meetsMinRating reports whether a place passes a client-side rating floor.
+// Unrated places are dropped once a floor is set.
+func meetsMinRating(place PlaceSummary, minRating *float64) bool {
+	if minRating == nil {
+		return true
+	}
+	return place.Rating != nil && *place.Rating >= *minRating
+}
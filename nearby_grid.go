@@ -0,0 +1,142 @@
+package goplaces
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+)
+
+const (
+	// maxGridTiles caps billed requests for a single grid search.
+	maxGridTiles    = 50
+	gridConcurrency = 4
+)
+
+// NearbyGrid enumerates places beyond the 20-result nearby cap. It tiles the
+// circle around center into overlapping circles spaced tileM meters apart,
+// runs a nearby search per tile concurrently, and merges results
+// deduplicated by place ID (first tile wins, tiles in row order).
+//
+// Every tile is a separately billed request; at most 50 tiles are allowed.
+// The LocationRestriction on req is ignored and replaced per tile.
+func (c *Client) NearbyGrid(
+	ctx context.Context,
+	center LatLng,
+	radiusM float64,
+	tileM float64,
+	req NearbySearchRequest,
+) (NearbySearchResponse, error) {
+	if err := validateLocationBias(&LocationBias{Lat: center.Lat, Lng: center.Lng, RadiusM: radiusM}); err != nil {
+		return NearbySearchResponse{}, err
+	}
+	if tileM <= 0 {
+		return NearbySearchResponse{}, ValidationError{Field: "tile_m", Message: "must be > 0"}
+	}
+	// Cells inside the inscribed square always survive gridTiles' circle
+	// test, so this lower bound rejects tiny tiles before the lattice is
+	// walked (radius 5km at 1m would otherwise be ~10^8 cells).
+	if inner := math.Floor(radiusM / tileM / math.Sqrt2); (2*inner+1)*(2*inner+1) > maxGridTiles {
+		return NearbySearchResponse{}, ValidationError{
+			Field:   "tile_m",
+			Message: fmt.Sprintf("grid needs over %d tiles (max %d); use a larger tile size", maxGridTiles, maxGridTiles),
+		}
+	}
+	tiles := gridTiles(center, radiusM, tileM)
+	if len(tiles) > maxGridTiles {
+		return NearbySearchResponse{}, ValidationError{
+			Field:   "tile_m",
+			Message: fmt.Sprintf("grid needs %d tiles (max %d); use a larger tile size", len(tiles), maxGridTiles),
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([][]PlaceSummary, len(tiles))
+	slots := make(chan struct{}, gridConcurrency)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for i, tile := range tiles {
+		wg.Add(1)
+		go func(i int, tile LocationBias) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			if ctx.Err() != nil {
+				return
+			}
+			tileReq := req
+			tileReq.LocationRestriction = &tile
+			response, err := c.NearbySearch(ctx, tileReq)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					// Stop the remaining tiles on the first failure.
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+			pages[i] = response.Results
+		}(i, tile)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return NearbySearchResponse{}, firstErr
+	}
+	// Tiles skipped because the caller's context ended leave no error of
+	// their own; partial results must not look complete.
+	if err := ctx.Err(); err != nil {
+		return NearbySearchResponse{}, err
+	}
+
+	seen := make(map[string]struct{})
+	results := make([]PlaceSummary, 0)
+	for _, page := range pages {
+		for _, place := range page {
+			if _, ok := seen[place.PlaceID]; ok {
+				continue
+			}
+			seen[place.PlaceID] = struct{}{}
+			results = append(results, place)
+		}
+	}
+	return NearbySearchResponse{Results: results}, nil
+}
+
+// gridTiles covers a circle with a square lattice of tile circles. Each tile
+// circumscribes its tileM-sized square cell, so neighbours overlap.
+func gridTiles(center LatLng, radiusM float64, tileM float64) []LocationBias {
+	tileRadius := tileM * math.Sqrt2 / 2
+	steps := int(math.Ceil(radiusM / tileM))
+	metersPerDegLat := earthRadiusMeters * math.Pi / 180
+	metersPerDegLng := metersPerDegLat * math.Cos(center.Lat*math.Pi/180)
+
+	tiles := make([]LocationBias, 0)
+	for row := -steps; row <= steps; row++ {
+		for col := -steps; col <= steps; col++ {
+			dy := float64(row) * tileM
+			dx := float64(col) * tileM
+			// Skip cells whose circle cannot touch the search area.
+			if math.Hypot(dx, dy) > radiusM+tileRadius {
+				continue
+			}
+			lng := center.Lng
+			if metersPerDegLng > 0 {
+				lng += dx / metersPerDegLng
+			}
+			tiles = append(tiles, LocationBias{
+				Lat:     center.Lat + dy/metersPerDegLat,
+				Lng:     lng,
+				RadiusM: tileRadius,
+			})
+		}
+	}
+	return tiles
+}
@@ -0,0 +1,134 @@
+package goplaces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNearbyGridDedupesAcrossTiles(t *testing.T) {
+	var mu sync.Mutex
+	centers := map[string]struct{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			LocationRestriction struct {
+				Circle struct {
+					Center struct {
+						Latitude  float64 `json:"latitude"`
+						Longitude float64 `json:"longitude"`
+					} `json:"center"`
+				} `json:"circle"`
+			} `json:"locationRestriction"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+			return
+		}
+		center := body.LocationRestriction.Circle.Center
+		id := fmt.Sprintf("%.5f,%.5f", center.Latitude, center.Longitude)
+		mu.Lock()
+		centers[id] = struct{}{}
+		mu.Unlock()
+		// Each tile returns its own place plus one shared place.
+		_, _ = fmt.Fprintf(w, `{"places": [{"id": %q}, {"id": "shared"}]}`, id)
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	response, err := client.NearbyGrid(context.Background(), LatLng{Lat: 47.6, Lng: -122.3}, 1000, 500, NearbySearchRequest{
+		IncludedTypes: []string{"cafe"},
+	})
+	if err != nil {
+		t.Fatalf("grid error: %v", err)
+	}
+
+	tiles := gridTiles(LatLng{Lat: 47.6, Lng: -122.3}, 1000, 500)
+	if len(centers) != len(tiles) {
+		t.Fatalf("expected %d distinct tile requests, got %d", len(tiles), len(centers))
+	}
+	if len(response.Results) != len(tiles)+1 {
+		t.Fatalf("expected %d unique places, got %d", len(tiles)+1, len(response.Results))
+	}
+	shared := 0
+	for _, place := range response.Results {
+		if place.PlaceID == "shared" {
+			shared++
+		}
+	}
+	if shared != 1 {
+		t.Fatalf("expected shared place once, got %d", shared)
+	}
+}
+
+func TestNearbyGridTileCap(t *testing.T) {
+	client := NewClient(Options{APIKey: "test-key", BaseURL: "http://127.0.0.1:0"})
+	_, err := client.NearbyGrid(context.Background(), LatLng{Lat: 1, Lng: 2}, 10000, 100, NearbySearchRequest{})
+	var validation ValidationError
+	if !errors.As(err, &validation) || validation.Field != "tile_m" {
+		t.Fatalf("expected tile cap error, got %v", err)
+	}
+}
+
+func TestNearbyGridRejectsTinyTilesUpFront(t *testing.T) {
+	client := NewClient(Options{APIKey: "test-key", BaseURL: "http://127.0.0.1:0"})
+	started := time.Now()
+	_, err := client.NearbyGrid(context.Background(), LatLng{Lat: 1, Lng: 2}, 50000, 0.001, NearbySearchRequest{})
+	var validation ValidationError
+	if !errors.As(err, &validation) || validation.Field != "tile_m" {
+		t.Fatalf("expected tile cap error, got %v", err)
+	}
+	if time.Since(started) > time.Second {
+		t.Fatalf("expected the cap to reject before walking the lattice")
+	}
+}
+
+func TestNearbyGridStopsOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	_, err := client.NearbyGrid(context.Background(), LatLng{Lat: 1, Lng: 2}, 1000, 500, NearbySearchRequest{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected api error, got %v", err)
+	}
+}
+
+func TestNearbyGridCancelledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	_, err := client.NearbyGrid(ctx, LatLng{Lat: 1, Lng: 2}, 1000, 500, NearbySearchRequest{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestGridTilesCoverArea(t *testing.T) {
+	center := LatLng{Lat: 0, Lng: 0}
+	tiles := gridTiles(center, 1000, 500)
+	if len(tiles) == 0 {
+		t.Fatalf("expected tiles")
+	}
+	for _, tile := range tiles {
+		if distanceMeters(center, LatLng{Lat: tile.Lat, Lng: tile.Lng}) > 1000+tile.RadiusM+1 {
+			t.Fatalf("tile outside search area: %#v", tile)
+		}
+	}
+	if single := gridTiles(center, 100, 1000); len(single) != 1 {
+		t.Fatalf("expected one tile for small radius, got %d", len(single))
+	}
+}
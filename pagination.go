@@ -0,0 +1,120 @@
+package goplaces
+
+import (
+	"context"
+	"fmt"
+)
+
+// PageState bundles a search request with its pagination token so callers
+// can walk pages without re-threading the original request.
+type PageState struct {
+	client  *Client
+	search  *SearchRequest
+	nearby  *NearbySearchRequest
+	token   string
+	started bool
+}
+
+// SearchPages starts paginating a text search. A PageToken on the request
+// resumes from that page.
+func (c *Client) SearchPages(req SearchRequest) *PageState {
+	return &PageState{client: c, search: &req, token: req.PageToken}
+}
+
+// NearbyPages starts paginating a nearby search.
+func (c *Client) NearbyPages(req NearbySearchRequest) *PageState {
+	return &PageState{client: c, nearby: &req}
+}
+
+// Token returns the token for the next page; empty once pages are exhausted.
+func (p *PageState) Token() string {
+	return p.token
+}
+
+// Next fetches the following page. It returns false, without error, once the
+// API stops returning page tokens. A failed fetch leaves the state unchanged
+// so it can be retried.
+func (p *PageState) Next(ctx context.Context) (SearchResponse, bool, error) {
+	if p.started && p.token == "" {
+		return SearchResponse{}, false, nil
+	}
+
+	var response SearchResponse
+	switch {
+	case p.search != nil:
+		req := *p.search
+		req.PageToken = p.token
+		page, err := p.client.Search(ctx, req)
+		if err != nil {
+			return SearchResponse{}, false, err
+		}
+		response = page
+	case p.nearby != nil:
+		page, err := p.client.NearbySearch(ctx, *p.nearby)
+		if err != nil {
+			return SearchResponse{}, false, err
+		}
+		// Nearby requests cannot carry a page token, so only one page is reachable.
+		response = SearchResponse{Results: page.Results}
+	default:
+		return SearchResponse{}, false, nil
+	}
+
+	p.started = true
+	p.token = response.NextPageToken
+	return response, true, nil
+}
+
+// SearchAll follows nextPageToken until the API stops returning one or
+// maxPages pages have been fetched (maxPages <= 0 means no cap), merging
+// results deduped by PlaceID. On a page error or context cancellation it
+// returns the results gathered so far together with the wrapped error.
+func (c *Client) SearchAll(ctx context.Context, req SearchRequest, maxPages int) (SearchResponse, error) {
+	pages := c.SearchPages(req)
+	merged := SearchResponse{Results: []PlaceSummary{}}
+	seen := make(map[string]struct{})
+	for page := 1; maxPages <= 0 || page <= maxPages; page++ {
+		if err := ctx.Err(); err != nil {
+			merged.NextPageToken = pages.Token()
+			return merged, fmt.Errorf("goplaces: search all page %d: %w", page, err)
+		}
+		response, ok, err := pages.Next(ctx)
+		if err != nil {
+			merged.NextPageToken = pages.Token()
+			return merged, fmt.Errorf("goplaces: search all page %d: %w", page, err)
+		}
+		if !ok {
+			break
+		}
+		for _, place := range response.Results {
+			if _, dup := seen[place.PlaceID]; dup {
+				continue
+			}
+			seen[place.PlaceID] = struct{}{}
+			merged.Results = append(merged.Results, place)
+		}
+	}
+	merged.NextPageToken = pages.Token()
+	return merged, nil
+}
+
+// SearchEach streams text search results one place at a time, following
+// page tokens until fn returns false or pages run out. Context cancellation
+// is checked between pages.
+func (c *Client) SearchEach(ctx context.Context, req SearchRequest, fn func(PlaceSummary) bool) error {
+	pages := c.SearchPages(req)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		response, ok, err := pages.Next(ctx)
+		if err != nil || !ok {
+			return err
+		}
+		for _, place := range response.Results {
+			if !fn(place) {
+				return nil
+			}
+		}
+	}
+}
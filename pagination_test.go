@@ -0,0 +1,231 @@
+package goplaces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPageStateTwoPages(t *testing.T) {
+	var tokens []any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["textQuery"] != "coffee" {
+			t.Fatalf("original request not reused: %#v", body)
+		}
+		tokens = append(tokens, body["pageToken"])
+		if body["pageToken"] == "page-2" {
+			_, _ = w.Write([]byte(`{"places": [{"id": "b"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"places": [{"id": "a"}], "nextPageToken": "page-2"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	pages := client.SearchPages(SearchRequest{Query: "coffee"})
+
+	first, ok, err := pages.Next(context.Background())
+	if err != nil || !ok {
+		t.Fatalf("first page: ok=%v err=%v", ok, err)
+	}
+	if len(first.Results) != 1 || first.Results[0].PlaceID != "a" || pages.Token() != "page-2" {
+		t.Fatalf("unexpected first page: %#v token=%q", first, pages.Token())
+	}
+
+	second, ok, err := pages.Next(context.Background())
+	if err != nil || !ok {
+		t.Fatalf("second page: ok=%v err=%v", ok, err)
+	}
+	if len(second.Results) != 1 || second.Results[0].PlaceID != "b" {
+		t.Fatalf("unexpected second page: %#v", second)
+	}
+
+	_, ok, err = pages.Next(context.Background())
+	if err != nil || ok {
+		t.Fatalf("expected exhausted pages, ok=%v err=%v", ok, err)
+	}
+	if len(tokens) != 2 || tokens[0] != nil || tokens[1] != "page-2" {
+		t.Fatalf("unexpected tokens: %#v", tokens)
+	}
+}
+
+func TestPageStateErrorIsRetryable(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"places": [{"id": "a"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	pages := client.SearchPages(SearchRequest{Query: "coffee"})
+	if _, _, err := pages.Next(context.Background()); err == nil {
+		t.Fatalf("expected error")
+	}
+	page, ok, err := pages.Next(context.Background())
+	if err != nil || !ok || len(page.Results) != 1 {
+		t.Fatalf("expected retry to succeed: %#v ok=%v err=%v", page, ok, err)
+	}
+}
+
+func TestPageStateNearbySinglePage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [{"id": "a"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	pages := client.NearbyPages(NearbySearchRequest{LocationRestriction: &LocationBias{Lat: 1, Lng: 2, RadiusM: 3}})
+	if _, ok, err := pages.Next(context.Background()); err != nil || !ok {
+		t.Fatalf("first page: ok=%v err=%v", ok, err)
+	}
+	if _, ok, _ := pages.Next(context.Background()); ok {
+		t.Fatalf("expected nearby pages to be exhausted")
+	}
+}
+
+func TestSearchAllMergesAndDedupes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		switch body["pageToken"] {
+		case nil:
+			_, _ = w.Write([]byte(`{"places": [{"id": "a"}, {"id": "b"}], "nextPageToken": "p2"}`))
+		case "p2":
+			_, _ = w.Write([]byte(`{"places": [{"id": "b"}, {"id": "c"}], "nextPageToken": "p3"}`))
+		default:
+			_, _ = w.Write([]byte(`{"places": [{"id": "d"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	response, err := client.SearchAll(context.Background(), SearchRequest{Query: "coffee"}, 0)
+	if err != nil {
+		t.Fatalf("search all error: %v", err)
+	}
+	ids := placeIDs(response.Results)
+	if ids != "a,b,c,d" || response.NextPageToken != "" {
+		t.Fatalf("unexpected merge: %s token=%q", ids, response.NextPageToken)
+	}
+
+	capped, err := client.SearchAll(context.Background(), SearchRequest{Query: "coffee"}, 2)
+	if err != nil {
+		t.Fatalf("search all error: %v", err)
+	}
+	if ids := placeIDs(capped.Results); ids != "a,b,c" || capped.NextPageToken != "p3" {
+		t.Fatalf("unexpected capped merge: %s token=%q", ids, capped.NextPageToken)
+	}
+}
+
+func TestSearchAllReturnsPartialResultsOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["pageToken"] == nil {
+			_, _ = w.Write([]byte(`{"places": [{"id": "a"}], "nextPageToken": "p2"}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("boom"))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	response, err := client.SearchAll(context.Background(), SearchRequest{Query: "coffee"}, 0)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected wrapped API error, got %v", err)
+	}
+	if placeIDs(response.Results) != "a" || response.NextPageToken != "p2" {
+		t.Fatalf("expected partial results, got %#v", response)
+	}
+}
+
+func TestSearchAllStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["pageToken"] != nil {
+			// Cancel mid-request and hold the response until the client gives up.
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+		_, _ = w.Write([]byte(`{"places": [{"id": "a"}], "nextPageToken": "p2"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	response, err := client.SearchAll(ctx, SearchRequest{Query: "coffee"}, 0)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled error, got %v", err)
+	}
+	if placeIDs(response.Results) != "a" {
+		t.Fatalf("expected first page results, got %#v", response.Results)
+	}
+}
+
+func placeIDs(places []PlaceSummary) string {
+	ids := make([]string, 0, len(places))
+	for _, place := range places {
+		ids = append(ids, place.PlaceID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestSearchEachStreamsPages(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["pageToken"] == "p2" {
+			_, _ = w.Write([]byte(`{"places": [{"id": "c"}, {"id": "d"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"places": [{"id": "a"}, {"id": "b"}], "nextPageToken": "p2"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	var seen []PlaceSummary
+	err := client.SearchEach(context.Background(), SearchRequest{Query: "coffee"}, func(place PlaceSummary) bool {
+		seen = append(seen, place)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("search each error: %v", err)
+	}
+	if placeIDs(seen) != "a,b,c,d" || calls != 2 {
+		t.Fatalf("unexpected stream: %s (calls=%d)", placeIDs(seen), calls)
+	}
+
+	calls = 0
+	seen = nil
+	err = client.SearchEach(context.Background(), SearchRequest{Query: "coffee"}, func(place PlaceSummary) bool {
+		seen = append(seen, place)
+		return place.PlaceID != "b"
+	})
+	if err != nil {
+		t.Fatalf("search each error: %v", err)
+	}
+	if placeIDs(seen) != "a,b" || calls != 1 {
+		t.Fatalf("expected early stop after first page: %s (calls=%d)", placeIDs(seen), calls)
+	}
+}
@@ -6,23 +6,102 @@ type searchResponse struct {
 }
 
 type placeItem struct {
-	ID                  string              `json:"id"`
-	DisplayName         *displayNamePayload `json:"displayName,omitempty"`
-	FormattedAddress    string              `json:"formattedAddress,omitempty"`
-	Location            *location           `json:"location,omitempty"`
-	Rating              *float64            `json:"rating,omitempty"`
-	PriceLevel          string              `json:"priceLevel,omitempty"`
-	Types               []string            `json:"types,omitempty"`
-	CurrentOpeningHours *openingHours       `json:"currentOpeningHours,omitempty"`
-	RegularOpeningHours *openingHours       `json:"regularOpeningHours,omitempty"`
-	NationalPhoneNumber string              `json:"nationalPhoneNumber,omitempty"`
-	WebsiteURI          string              `json:"websiteUri,omitempty"`
-	Reviews             []reviewPayload     `json:"reviews,omitempty"`
-	Photos              []photoPayload      `json:"photos,omitempty"`
+	ID                       string                       `json:"id"`
+	DisplayName              *displayNamePayload          `json:"displayName,omitempty"`
+	FormattedAddress         string                       `json:"formattedAddress,omitempty"`
+	ShortFormattedAddress    string                       `json:"shortFormattedAddress,omitempty"`
+	Location                 *location                    `json:"location,omitempty"`
+	Rating                   *float64                     `json:"rating,omitempty"`
+	UserRatingCount          *int                         `json:"userRatingCount,omitempty"`
+	PriceLevel               string                       `json:"priceLevel,omitempty"`
+	PriceRange               *priceRangePayload           `json:"priceRange,omitempty"`
+	Types                    []string                     `json:"types,omitempty"`
+	CurrentOpeningHours      *openingHours                `json:"currentOpeningHours,omitempty"`
+	RegularOpeningHours      *openingHours                `json:"regularOpeningHours,omitempty"`
+	NationalPhoneNumber      string                       `json:"nationalPhoneNumber,omitempty"`
+	InternationalPhoneNumber string                       `json:"internationalPhoneNumber,omitempty"`
+	WebsiteURI               string                       `json:"websiteUri,omitempty"`
+	Reviews                  []reviewPayload              `json:"reviews,omitempty"`
+	Photos                   []photoPayload               `json:"photos,omitempty"`
+	BusinessStatus           string                       `json:"businessStatus,omitempty"`
+	UTCOffsetMinutes         *int                         `json:"utcOffsetMinutes,omitempty"`
+	AddressComponents        []addressComponentPayload    `json:"addressComponents,omitempty"`
+	Viewport                 *viewportPayload             `json:"viewport,omitempty"`
+	PlusCode                 *plusCodePayload             `json:"plusCode,omitempty"`
+	EVChargeOptions          *evChargeOptionsPayload      `json:"evChargeOptions,omitempty"`
+	AccessibilityOptions     *accessibilityOptionsPayload `json:"accessibilityOptions,omitempty"`
+	DineIn                   *bool                        `json:"dineIn,omitempty"`
+	Takeout                  *bool                        `json:"takeout,omitempty"`
+	Delivery                 *bool                        `json:"delivery,omitempty"`
+	CurbsidePickup           *bool                        `json:"curbsidePickup,omitempty"`
+	Reservable               *bool                        `json:"reservable,omitempty"`
+	ServesBeer               *bool                        `json:"servesBeer,omitempty"`
+	ServesWine               *bool                        `json:"servesWine,omitempty"`
+	ServesCocktails          *bool                        `json:"servesCocktails,omitempty"`
+	ServesBreakfast          *bool                        `json:"servesBreakfast,omitempty"`
+	ServesBrunch             *bool                        `json:"servesBrunch,omitempty"`
+	ServesLunch              *bool                        `json:"servesLunch,omitempty"`
+	ServesDinner             *bool                        `json:"servesDinner,omitempty"`
+	ServesVegetarianFood     *bool                        `json:"servesVegetarianFood,omitempty"`
+	OutdoorSeating           *bool                        `json:"outdoorSeating,omitempty"`
+	LiveMusic                *bool                        `json:"liveMusic,omitempty"`
+	MenuForChildren          *bool                        `json:"menuForChildren,omitempty"`
+	AllowsDogs               *bool                        `json:"allowsDogs,omitempty"`
+}
+
+type accessibilityOptionsPayload struct {
+	WheelchairAccessibleParking  *bool `json:"wheelchairAccessibleParking,omitempty"`
+	WheelchairAccessibleEntrance *bool `json:"wheelchairAccessibleEntrance,omitempty"`
+	WheelchairAccessibleRestroom *bool `json:"wheelchairAccessibleRestroom,omitempty"`
+	WheelchairAccessibleSeating  *bool `json:"wheelchairAccessibleSeating,omitempty"`
+}
+
+type evChargeOptionsPayload struct {
+	ConnectorCount       int                             `json:"connectorCount,omitempty"`
+	ConnectorAggregation []evConnectorAggregationPayload `json:"connectorAggregation,omitempty"`
+}
+
+type evConnectorAggregationPayload struct {
+	Type                       string   `json:"type,omitempty"`
+	MaxChargeRateKW            *float64 `json:"maxChargeRateKw,omitempty"`
+	Count                      int      `json:"count,omitempty"`
+	AvailableCount             *int     `json:"availableCount,omitempty"`
+	OutOfServiceCount          *int     `json:"outOfServiceCount,omitempty"`
+	AvailabilityLastUpdateTime string   `json:"availabilityLastUpdateTime,omitempty"`
+}
+
+type plusCodePayload struct {
+	GlobalCode   string `json:"globalCode,omitempty"`
+	CompoundCode string `json:"compoundCode,omitempty"`
+}
+
+type viewportPayload struct {
+	Low  *location `json:"low,omitempty"`
+	High *location `json:"high,omitempty"`
+}
+
+type addressComponentPayload struct {
+	LongText  string   `json:"longText,omitempty"`
+	ShortText string   `json:"shortText,omitempty"`
+	Types     []string `json:"types,omitempty"`
 }
 
 type displayNamePayload struct {
-	Text string `json:"text"`
+	Text         string `json:"text"`
+	LanguageCode string `json:"languageCode,omitempty"`
+}
+
+type priceRangePayload struct {
+	StartPrice *moneyPayload `json:"startPrice,omitempty"`
+	EndPrice   *moneyPayload `json:"endPrice,omitempty"`
+}
+
+// moneyPayload is google.type.Money: units is an int64, which proto JSON
+// encodes as a string, and nanos carries the fraction with the same sign.
+type moneyPayload struct {
+	CurrencyCode string `json:"currencyCode,omitempty"`
+	Units        string `json:"units,omitempty"`
+	Nanos        int32  `json:"nanos,omitempty"`
 }
 
 type location struct {
@@ -31,8 +110,20 @@ type location struct {
 }
 
 type openingHours struct {
-	OpenNow             *bool    `json:"openNow,omitempty"`
-	WeekdayDescriptions []string `json:"weekdayDescriptions,omitempty"`
+	OpenNow             *bool           `json:"openNow,omitempty"`
+	WeekdayDescriptions []string        `json:"weekdayDescriptions,omitempty"`
+	Periods             []periodPayload `json:"periods,omitempty"`
+}
+
+type periodPayload struct {
+	Open  *timeOfWeekPayload `json:"open,omitempty"`
+	Close *timeOfWeekPayload `json:"close,omitempty"`
+}
+
+type timeOfWeekPayload struct {
+	Day    int `json:"day"`
+	Hour   int `json:"hour"`
+	Minute int `json:"minute"`
 }
 
 type reviewPayload struct {
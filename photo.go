@@ -3,17 +3,45 @@ package goplaces
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
 )
 
+// maxPhotoBytes caps PhotoBytes downloads; Places photos top out at 4800px.
+const maxPhotoBytes = 25 << 20
+
+// ErrPhotoTooLarge is returned when a photo exceeds the download cap.
+var ErrPhotoTooLarge = errors.New("goplaces: photo exceeds download limit")
+
 // PhotoMedia fetches a photo URL for a photo resource name.
 func (c *Client) PhotoMedia(ctx context.Context, req PhotoMediaRequest) (PhotoMediaResponse, error) {
+	endpoint, err := c.photoMediaEndpoint(req)
+	if err != nil {
+		return PhotoMediaResponse{}, err
+	}
+
+	payload, err := c.doRequest(ctx, http.MethodGet, endpoint, nil, "")
+	if err != nil {
+		return PhotoMediaResponse{}, err
+	}
+
+	var response photoMediaPayload
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return PhotoMediaResponse{}, fmt.Errorf("goplaces: decode photo media response: %w", err)
+	}
+
+	return PhotoMediaResponse(response), nil
+}
+
+// photoMediaEndpoint validates req and builds its media URL.
+func (c *Client) photoMediaEndpoint(req PhotoMediaRequest) (string, error) {
 	name := strings.TrimSpace(req.Name)
 	if name == "" {
-		return PhotoMediaResponse{}, ValidationError{Field: "name", Message: "required"}
+		return "", ValidationError{Field: "name", Message: "required"}
 	}
 
 	path := "/" + strings.TrimPrefix(name, "/") + "/media"
@@ -24,23 +52,52 @@ func (c *Client) PhotoMedia(ctx context.Context, req PhotoMediaRequest) (PhotoMe
 	if req.MaxHeightPx > 0 {
 		query["maxHeightPx"] = strconv.Itoa(req.MaxHeightPx)
 	}
+	return c.buildURL(path, query)
+}
 
-	endpoint, err := c.buildURL(path, query)
+// PhotoBytes resolves the photo URI via PhotoMedia and streams the image
+// bytes to w. The API key is only sent to the Places API, never to the
+// photo host. Downloads above 25 MiB fail with ErrPhotoTooLarge, after
+// partial bytes may already have been written.
+func (c *Client) PhotoBytes(ctx context.Context, req PhotoMediaRequest, w io.Writer) (PhotoDownload, error) {
+	media, err := c.PhotoMedia(ctx, req)
 	if err != nil {
-		return PhotoMediaResponse{}, err
+		return PhotoDownload{}, err
+	}
+	if strings.TrimSpace(media.PhotoURI) == "" {
+		return PhotoDownload{}, errors.New("goplaces: photo media response has no uri")
 	}
 
-	payload, err := c.doRequest(ctx, http.MethodGet, endpoint, nil, "")
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, media.PhotoURI, nil)
 	if err != nil {
-		return PhotoMediaResponse{}, err
+		return PhotoDownload{}, fmt.Errorf("goplaces: build photo request: %w", err)
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return PhotoDownload{}, fmt.Errorf("goplaces: photo download failed: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode >= http.StatusBadRequest {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 4<<10))
+		return PhotoDownload{}, &APIError{StatusCode: response.StatusCode, Body: strings.TrimSpace(string(body))}
 	}
 
-	var response photoMediaPayload
-	if err := json.Unmarshal(payload, &response); err != nil {
-		return PhotoMediaResponse{}, fmt.Errorf("goplaces: decode photo media response: %w", err)
+	written, err := io.Copy(w, io.LimitReader(response.Body, maxPhotoBytes+1))
+	if err != nil {
+		return PhotoDownload{}, fmt.Errorf("goplaces: photo download failed: %w", err)
+	}
+	if written > maxPhotoBytes {
+		return PhotoDownload{}, ErrPhotoTooLarge
 	}
 
-	return PhotoMediaResponse(response), nil
+	return PhotoDownload{
+		Name:        media.Name,
+		PhotoURI:    media.PhotoURI,
+		ContentType: response.Header.Get("Content-Type"),
+		Bytes:       written,
+	}, nil
 }
 
 type photoMediaPayload struct {
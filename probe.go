@@ -0,0 +1,95 @@
+package goplaces
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// probeFieldMask requests IDs only, the cheapest Text Search SKU.
+const probeFieldMask = "places.id"
+
+// ProbeResult reports the outcome of a minimal live request.
+type ProbeResult struct {
+	URL        string        `json:"url"`
+	StatusCode int           `json:"status_code"`
+	ServerTime time.Time     `json:"server_time,omitzero"`
+	Latency    time.Duration `json:"latency"`
+}
+
+// ProbePlaces sends a minimal IDs-only text search to verify the API key and
+// that the Places API (New) is enabled. HTTP error statuses are reported in
+// the result rather than returned as errors.
+func (c *Client) ProbePlaces(ctx context.Context) (ProbeResult, error) {
+	if strings.TrimSpace(c.apiKey) == "" {
+		return ProbeResult{}, ErrMissingAPIKey
+	}
+	endpoint, err := c.buildURL("/places:searchText", nil)
+	if err != nil {
+		return ProbeResult{}, err
+	}
+	payload, err := json.Marshal(probePlacesBody())
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("goplaces: encode request: %w", err)
+	}
+	return c.probe(ctx, http.MethodPost, endpoint, payload, map[string]string{
+		"Content-Type":     "application/json",
+		"X-Goog-Api-Key":   c.apiKey,
+		"X-Goog-FieldMask": probeFieldMask,
+	})
+}
+
+func probePlacesBody() map[string]any {
+	return map[string]any{"textQuery": "coffee", "pageSize": 1}
+}
+
+// ProbeRoutes checks that the Routes API base URL answers HTTP requests.
+// Any HTTP status counts as reachable; no API key is sent.
+func (c *Client) ProbeRoutes(ctx context.Context) (ProbeResult, error) {
+	return c.probe(ctx, http.MethodGet, c.routesBaseURL+"/", nil, nil)
+}
+
+func (c *Client) probe(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	body []byte,
+	headers map[string]string,
+) (ProbeResult, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("goplaces: build request: %w", err)
+	}
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	started := time.Now()
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("goplaces: request failed: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 1<<20))
+
+	result := ProbeResult{
+		URL:        endpoint,
+		StatusCode: response.StatusCode,
+		Latency:    time.Since(started),
+	}
+	if date, err := http.ParseTime(response.Header.Get("Date")); err == nil {
+		result.ServerTime = date
+	}
+	return result, nil
+}
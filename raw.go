@@ -0,0 +1,53 @@
+package goplaces
+
+import (
+	"context"
+	"strings"
+)
+
+// SearchRaw runs a single text search page and returns the API's JSON body
+// unmapped, so fields goplaces does not model survive. Fields may name any
+// Place field; there is no client-side paging past one page.
+func (c *Client) SearchRaw(ctx context.Context, req SearchRequest) ([]byte, error) {
+	req = applySearchDefaults(req)
+	if err := validateSearchRequest(req); err != nil {
+		return nil, err
+	}
+	if err := validateRawFields(req.Fields); err != nil {
+		return nil, err
+	}
+	return c.searchPayload(ctx, req)
+}
+
+// NearbySearchRaw returns the unmapped nearby search JSON body. MinRating is
+// not applied since it filters client-side.
+func (c *Client) NearbySearchRaw(ctx context.Context, req NearbySearchRequest) ([]byte, error) {
+	req = applyNearbyDefaults(req)
+	if err := validateNearbyRequest(req); err != nil {
+		return nil, err
+	}
+	return c.nearbyPayload(ctx, req)
+}
+
+// DetailsRaw returns the unmapped place details JSON body. Fields may name
+// any Place field.
+func (c *Client) DetailsRaw(ctx context.Context, req DetailsRequest) ([]byte, error) {
+	if strings.TrimSpace(req.PlaceID) == "" {
+		return nil, ValidationError{Field: "place_id", Message: "required"}
+	}
+	if err := validateRawFields(req.Fields); err != nil {
+		return nil, err
+	}
+	return c.detailsPayload(ctx, req)
+}
+
+// validateRawFields only rejects blank names; raw callers may ask for fields
+// the mappers do not know.
+func validateRawFields(fields []string) error {
+	for _, field := range fields {
+		if strings.TrimSpace(field) == "" {
+			return ValidationError{Field: "fields", Message: "must not contain empty names"}
+		}
+	}
+	return nil
+}
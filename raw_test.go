@@ -0,0 +1,73 @@
+package goplaces
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchRawKeepsUnmodeledFields(t *testing.T) {
+	body := `{"places": [{"id": "abc", "editorialSummary": {"text": "Cozy"}}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Goog-FieldMask"); got != "places.id,places.editorialSummary,nextPageToken" {
+			t.Fatalf("unexpected field mask: %s", got)
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	payload, err := client.SearchRaw(context.Background(), SearchRequest{Query: "coffee", Fields: []string{"editorialSummary"}})
+	if err != nil {
+		t.Fatalf("search raw error: %v", err)
+	}
+	if string(payload) != body {
+		t.Fatalf("expected payload unchanged, got %s", payload)
+	}
+}
+
+func TestDetailsAndNearbyRaw(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/places/abc":
+			if got := r.Header.Get("X-Goog-FieldMask"); got != "id,googleMapsUri" {
+				t.Fatalf("unexpected field mask: %s", got)
+			}
+			_, _ = w.Write([]byte(`{"id": "abc", "googleMapsUri": "https://maps.google.com/?cid=1"}`))
+		case "/places:searchNearby":
+			_, _ = w.Write([]byte(`{"places": [{"id": "low", "rating": 2}]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	payload, err := client.DetailsRaw(context.Background(), DetailsRequest{PlaceID: "abc", Fields: []string{"googleMapsUri"}})
+	if err != nil || string(payload) != `{"id": "abc", "googleMapsUri": "https://maps.google.com/?cid=1"}` {
+		t.Fatalf("unexpected details raw: %s (%v)", payload, err)
+	}
+
+	// MinRating filters mapped results only; the raw body is untouched.
+	minRating := 4.0
+	payload, err = client.NearbySearchRaw(context.Background(), NearbySearchRequest{
+		LocationRestriction: &LocationBias{Lat: 1, Lng: 2, RadiusM: 100},
+		MinRating:           &minRating,
+	})
+	if err != nil || string(payload) != `{"places": [{"id": "low", "rating": 2}]}` {
+		t.Fatalf("unexpected nearby raw: %s (%v)", payload, err)
+	}
+}
+
+func TestRawValidation(t *testing.T) {
+	client := NewClient(Options{APIKey: "test-key", BaseURL: "http://127.0.0.1:0"})
+	var validation ValidationError
+	if _, err := client.SearchRaw(context.Background(), SearchRequest{Query: "coffee", Fields: []string{" "}}); !errors.As(err, &validation) || validation.Field != "fields" {
+		t.Fatalf("expected fields validation error, got %v", err)
+	}
+	if _, err := client.DetailsRaw(context.Background(), DetailsRequest{}); !errors.As(err, &validation) || validation.Field != "place_id" {
+		t.Fatalf("expected place_id validation error, got %v", err)
+	}
+}
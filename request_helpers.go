@@ -1,13 +1,25 @@
 package goplaces
 
-func circlePayload(bias *LocationBias) map[string]any {
+func locationBiasPayload(bias *LocationBias) map[string]any {
+	if bias.Rectangle != nil {
+		return map[string]any{
+			"rectangle": map[string]any{
+				"low":  latLngPayload(bias.Rectangle.SW),
+				"high": latLngPayload(bias.Rectangle.NE),
+			},
+		}
+	}
 	return map[string]any{
 		"circle": map[string]any{
-			"center": map[string]any{
-				"latitude":  bias.Lat,
-				"longitude": bias.Lng,
-			},
+			"center": latLngPayload(LatLng{Lat: bias.Lat, Lng: bias.Lng}),
 			"radius": bias.RadiusM,
 		},
 	}
 }
+
+func latLngPayload(point LatLng) map[string]any {
+	return map[string]any{
+		"latitude":  point.Lat,
+		"longitude": point.Lng,
+	}
+}
@@ -16,23 +16,17 @@ func (c *Client) Resolve(ctx context.Context, req LocationResolveRequest) (Locat
 	if err := validateResolveRequest(req); err != nil {
 		return LocationResolveResponse{}, err
 	}
-
-	body := map[string]any{
-		"textQuery": req.LocationText,
-		"pageSize":  req.Limit,
-	}
-	if strings.TrimSpace(req.Language) != "" {
-		body["languageCode"] = strings.TrimSpace(req.Language)
-	}
-	if strings.TrimSpace(req.Region) != "" {
-		body["regionCode"] = strings.TrimSpace(req.Region)
+	language, region, err := c.locale(req.Language, req.Region)
+	if err != nil {
+		return LocationResolveResponse{}, err
 	}
+	req.Language, req.Region = language, region
 
 	endpoint, err := c.buildURL("/places:searchText", nil)
 	if err != nil {
 		return LocationResolveResponse{}, err
 	}
-	payload, err := c.doRequest(ctx, http.MethodPost, endpoint, body, resolveFieldMask)
+	payload, err := c.doClampedRequest(ctx, http.MethodPost, endpoint, buildResolveBody(req), resolveFieldMask, "pageSize", maxResolveLimit)
 	if err != nil {
 		return LocationResolveResponse{}, err
 	}
@@ -50,6 +44,20 @@ func (c *Client) Resolve(ctx context.Context, req LocationResolveRequest) (Locat
 	return LocationResolveResponse{Results: results}, nil
 }
 
+func buildResolveBody(req LocationResolveRequest) map[string]any {
+	body := map[string]any{
+		"textQuery": req.LocationText,
+		"pageSize":  req.Limit,
+	}
+	if strings.TrimSpace(req.Language) != "" {
+		body["languageCode"] = strings.TrimSpace(req.Language)
+	}
+	if strings.TrimSpace(req.Region) != "" {
+		body["regionCode"] = strings.TrimSpace(req.Region)
+	}
+	return body
+}
+
 func mapResolvedLocation(place placeItem) ResolvedLocation {
 	return ResolvedLocation{
 		PlaceID:  place.ID,
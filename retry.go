@@ -0,0 +1,116 @@
+package goplaces
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxRetriesLimit is the largest Options.MaxRetries honored; NewClient
+// clamps larger values to it.
+const MaxRetriesLimit = 10
+
+const (
+	defaultRetryBackoff  = 250 * time.Millisecond
+	defaultRetryMaxDelay = 30 * time.Second
+	// maxBackoffShift bounds base << attempt well below int64 overflow.
+	maxBackoffShift = 20
+)
+
+// retryable reports whether err is a transient API status worth retrying.
+func retryable(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// takeRetry claims one retry from the client-wide budget, reporting false
+// once it is spent.
+func (c *Client) takeRetry() bool {
+	if c.retryBudget == nil {
+		return true
+	}
+	return c.retryBudget.Add(-1) >= 0
+}
+
+// retryDelay doubles base per attempt and jitters it to 50-150%. A server
+// Retry-After takes precedence. Either is clamped to maxDelay, or to
+// defaultRetryMaxDelay when maxDelay is unset.
+func retryDelay(base, maxDelay time.Duration, attempt int, retryAfter time.Duration) time.Duration {
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+	delay := retryAfter
+	if delay <= 0 {
+		delay = base << min(attempt, maxBackoffShift)
+		if delay >= maxDelay {
+			return maxDelay
+		}
+		delay = delay/2 + rand.N(delay+1)
+	}
+	if delay > maxDelay || delay < 0 {
+		return maxDelay
+	}
+	return delay
+}
+
+// parseRetryAfter accepts delta-seconds or an HTTP date (the forms
+// http.ParseTime knows, plus RFC 1123 with a numeric or non-GMT zone).
+// 0 means absent, past, or malformed, so the caller falls back to its
+// computed backoff.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	for _, parse := range []func(string) (time.Time, error){
+		http.ParseTime,
+		// Some servers send RFC 1123 with a zone other than the literal GMT.
+		func(value string) (time.Time, error) { return time.Parse(time.RFC1123, value) },
+		func(value string) (time.Time, error) { return time.Parse(time.RFC1123Z, value) },
+	} {
+		if at, err := parse(value); err == nil {
+			if at.After(now) {
+				return at.Sub(now)
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+// waitRetry sleeps for delay, failing fast if the context is done or its
+// deadline would pass before the retry could start.
+func waitRetry(ctx context.Context, delay time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return context.DeadlineExceeded
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,277 @@
+package goplaces
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransientThenSuccess(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("busy"))
+			return
+		}
+		_, _ = w.Write([]byte(`{"places": [{"id": "abc"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, MaxRetries: 3, RetryBackoff: time.Millisecond})
+	response, err := client.Search(context.Background(), SearchRequest{Query: "coffee"})
+	if err != nil {
+		t.Fatalf("search error: %v", err)
+	}
+	if len(response.Results) != 1 || calls != 3 {
+		t.Fatalf("expected success after 2 retries, calls=%d results=%#v", calls, response.Results)
+	}
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, MaxRetries: 2, RetryBackoff: time.Millisecond})
+	_, err := client.Search(context.Background(), SearchRequest{Query: "coffee"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || calls != 3 {
+		t.Fatalf("expected 429 after 3 calls, calls=%d err=%v", calls, err)
+	}
+}
+
+func TestRetrySkipsClientErrors(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden} {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls++
+			w.WriteHeader(status)
+		}))
+
+		client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, MaxRetries: 3, RetryBackoff: time.Millisecond})
+		_, err := client.Search(context.Background(), SearchRequest{Query: "coffee"})
+		server.Close()
+		if err == nil || calls != 1 {
+			t.Fatalf("status %d: expected a single call, got %d (err=%v)", status, calls, err)
+		}
+	}
+}
+
+func TestRetryRespectsDeadline(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, MaxRetries: 3})
+	started := time.Now()
+	_, err := client.Search(ctx, SearchRequest{Query: "coffee"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || calls != 1 {
+		t.Fatalf("expected immediate 503 without retry, calls=%d err=%v", calls, err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline error to be wrapped, got %v", err)
+	}
+	if time.Since(started) > 500*time.Millisecond {
+		t.Fatalf("expected no wait past the deadline")
+	}
+}
+
+func TestRetryCancelledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		// Cancel once the client is committed to waiting out the backoff.
+		time.AfterFunc(20*time.Millisecond, cancel)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, MaxRetries: 3, RetryBackoff: 10 * time.Second})
+	_, err := client.Search(ctx, SearchRequest{Query: "coffee"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable || calls != 1 {
+		t.Fatalf("expected the last 503 to stay reachable, calls=%d err=%v", calls, err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if got := parseRetryAfter("7", now); got != 7*time.Second {
+		t.Fatalf("unexpected seconds: %v", got)
+	}
+	if got := parseRetryAfter("120", now); got != 120*time.Second {
+		t.Fatalf("unexpected seconds: %v", got)
+	}
+	if got := parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now); got != 90*time.Second {
+		t.Fatalf("unexpected date delay: %v", got)
+	}
+	if got := parseRetryAfter("soon", now); got != 0 {
+		t.Fatalf("expected 0 for invalid value, got %v", got)
+	}
+}
+
+func TestRetryDelayBounds(t *testing.T) {
+	for attempt := 0; attempt < 4; attempt++ {
+		base := 100 * time.Millisecond << attempt
+		delay := retryDelay(100*time.Millisecond, time.Minute, attempt, 0)
+		if delay < base/2 || delay > base*3/2 {
+			t.Fatalf("attempt %d: delay %v outside jitter bounds", attempt, delay)
+		}
+	}
+	if got := retryDelay(time.Millisecond, time.Minute, 0, 3*time.Second); got != 3*time.Second {
+		t.Fatalf("expected Retry-After to win, got %v", got)
+	}
+}
+
+func TestRetryDelayClampsToMaxDelay(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	maxDelay := 30 * time.Second
+	if got := retryDelay(time.Millisecond, maxDelay, 0, parseRetryAfter("120", now)); got != maxDelay {
+		t.Fatalf("expected seconds clamped to %v, got %v", maxDelay, got)
+	}
+	future := now.Add(10 * time.Minute).Format(time.RFC1123)
+	if got := retryDelay(time.Millisecond, maxDelay, 0, parseRetryAfter(future, now)); got != maxDelay {
+		t.Fatalf("expected date clamped to %v, got %v", maxDelay, got)
+	}
+	// A malformed header falls back to the jittered backoff.
+	if got := retryDelay(100*time.Millisecond, maxDelay, 0, parseRetryAfter("garbage", now)); got < 50*time.Millisecond || got > 150*time.Millisecond {
+		t.Fatalf("expected backoff for malformed value, got %v", got)
+	}
+	if got := retryDelay(time.Second, maxDelay, 10, 0); got != maxDelay {
+		t.Fatalf("expected backoff clamped to %v, got %v", maxDelay, got)
+	}
+}
+
+func TestRetryDelayDefaultCap(t *testing.T) {
+	if got := retryDelay(time.Millisecond, 0, 0, 3*time.Hour); got != defaultRetryMaxDelay {
+		t.Fatalf("expected Retry-After capped to %v, got %v", defaultRetryMaxDelay, got)
+	}
+}
+
+func TestRetryDelayLargeAttempt(t *testing.T) {
+	for _, attempt := range []int{36, 63, 64, 1000} {
+		if got := retryDelay(time.Second, 30*time.Second, attempt, 0); got != 30*time.Second {
+			t.Fatalf("attempt %d: expected %v, got %v", attempt, 30*time.Second, got)
+		}
+	}
+}
+
+func TestNewClientClampsMaxRetries(t *testing.T) {
+	if got := NewClient(Options{MaxRetries: 1000}).maxRetries; got != MaxRetriesLimit {
+		t.Fatalf("expected %d, got %d", MaxRetriesLimit, got)
+	}
+	if got := NewClient(Options{MaxRetries: -1}).maxRetries; got != 0 {
+		t.Fatalf("expected 0, got %d", got)
+	}
+}
+
+func TestRetryBudgetSharedAcrossRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{
+		APIKey:          "test-key",
+		BaseURL:         server.URL,
+		MaxRetries:      3,
+		MaxTotalRetries: 4,
+		RetryBackoff:    time.Millisecond,
+	})
+	// First request: 1 call + 3 retries (budget 4 -> 1).
+	if _, err := client.Search(context.Background(), SearchRequest{Query: "coffee"}); err == nil {
+		t.Fatalf("expected error")
+	}
+	if got := calls.Load(); got != 4 {
+		t.Fatalf("expected 4 calls for first request, got %d", got)
+	}
+	// Second request: 1 call + the last retry.
+	if _, err := client.Search(context.Background(), SearchRequest{Query: "coffee"}); err == nil {
+		t.Fatalf("expected error")
+	}
+	if got := calls.Load(); got != 6 {
+		t.Fatalf("expected 6 calls after second request, got %d", got)
+	}
+	// Budget spent: fail fast after a single call.
+	_, err := client.Search(context.Background(), SearchRequest{Query: "coffee"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 api error, got %v", err)
+	}
+	if got := calls.Load(); got != 7 {
+		t.Fatalf("expected no retries once budget is spent, got %d calls", got)
+	}
+}
+
+func TestRateLimitPacesRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"id": "abc"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, RateLimit: 20})
+	start := time.Now()
+	for range 3 {
+		if _, err := client.Details(context.Background(), "abc"); err != nil {
+			t.Fatalf("details error: %v", err)
+		}
+	}
+	// Burst 1 at 20/s: the 2nd and 3rd requests each wait ~50ms.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("expected paced requests, took %v", elapsed)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestRateLimitHonorsContext(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"id": "abc"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, RateLimit: 0.01, RateBurst: 1})
+	if _, err := client.Details(context.Background(), "abc"); err != nil {
+		t.Fatalf("details error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.Details(ctx, "abc"); err == nil {
+		t.Fatalf("expected rate limit wait to fail")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected early return, took %v", elapsed)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected the limited request to be skipped, calls=%d", calls.Load())
+	}
+}
@@ -0,0 +1,110 @@
+package goplaces
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	reverseFieldMask = "places.id,places.displayName,places.formattedAddress,places.location,places.types"
+	// reverseRadiusM is how far from the coordinates a place may be.
+	reverseRadiusM = 50
+	// reverseCandidates gives the nearest-place pick a few options.
+	reverseCandidates = 5
+)
+
+// ReverseRequest looks up the place nearest to a coordinate.
+type ReverseRequest struct {
+	Lat      float64 `json:"lat"`
+	Lng      float64 `json:"lng"`
+	Language string  `json:"language,omitempty"`
+	Region   string  `json:"region,omitempty"`
+}
+
+// Reverse returns the place nearest to lat/lng.
+func (c *Client) Reverse(ctx context.Context, lat, lng float64) (ResolvedLocation, error) {
+	return c.ReverseWithOptions(ctx, ReverseRequest{Lat: lat, Lng: lng})
+}
+
+// ReverseWithOptions returns the place nearest to the request coordinates,
+// using a distance-ranked nearby search within 50m. It returns
+// ErrNoPlaceFound when nothing is that close.
+func (c *Client) ReverseWithOptions(ctx context.Context, req ReverseRequest) (ResolvedLocation, error) {
+	if err := validateReverseRequest(req); err != nil {
+		return ResolvedLocation{}, err
+	}
+	language, region, err := c.locale(req.Language, req.Region)
+	if err != nil {
+		return ResolvedLocation{}, err
+	}
+	req.Language, req.Region = language, region
+
+	endpoint, err := c.buildURL("/places:searchNearby", nil)
+	if err != nil {
+		return ResolvedLocation{}, err
+	}
+	payload, err := c.doRequest(ctx, http.MethodPost, endpoint, buildReverseBody(req), reverseFieldMask)
+	if err != nil {
+		return ResolvedLocation{}, err
+	}
+
+	var response searchResponse
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return ResolvedLocation{}, fmt.Errorf("goplaces: decode reverse response: %w", err)
+	}
+
+	return nearestLocation(response.Places, LatLng{Lat: req.Lat, Lng: req.Lng})
+}
+
+func buildReverseBody(req ReverseRequest) map[string]any {
+	restriction := &LocationBias{Lat: req.Lat, Lng: req.Lng, RadiusM: reverseRadiusM}
+	body := map[string]any{
+		"locationRestriction": locationBiasPayload(restriction),
+		"maxResultCount":      reverseCandidates,
+		"rankPreference":      RankPreferenceDistance,
+	}
+	if strings.TrimSpace(req.Language) != "" {
+		body["languageCode"] = strings.TrimSpace(req.Language)
+	}
+	if strings.TrimSpace(req.Region) != "" {
+		body["regionCode"] = strings.TrimSpace(req.Region)
+	}
+	return body
+}
+
+func validateReverseRequest(req ReverseRequest) error {
+	if req.Lat < -90 || req.Lat > 90 {
+		return ValidationError{Field: "lat", Message: "must be -90..90"}
+	}
+	if req.Lng < -180 || req.Lng > 180 {
+		return ValidationError{Field: "lng", Message: "must be -180..180"}
+	}
+	return nil
+}
+
+// nearestLocation picks the closest place to point. The API already ranks
+// by distance; re-checking keeps the pick stable if it ever does not.
+func nearestLocation(places []placeItem, point LatLng) (ResolvedLocation, error) {
+	var (
+		best     ResolvedLocation
+		bestDist float64
+		found    bool
+	)
+	for _, place := range places {
+		candidate := mapResolvedLocation(place)
+		if candidate.Location == nil {
+			continue
+		}
+		dist := distanceMeters(point, *candidate.Location)
+		if !found || dist < bestDist {
+			best, bestDist, found = candidate, dist, true
+		}
+	}
+	if !found {
+		return ResolvedLocation{}, ErrNoPlaceFound
+	}
+	return best, nil
+}
@@ -6,9 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/rand/v2"
 	"net/http"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -18,12 +21,17 @@ const (
 )
 
 const (
-	defaultRouteLimit      = 5
-	defaultRouteRadiusM    = 1000
-	defaultRouteWaypoints  = 5
-	maxRouteWaypoints      = 20
+	defaultRouteLimit       = 5
+	defaultRouteRadiusM     = 1000
+	defaultRouteWaypoints   = 5
+	defaultRouteConcurrency = 4
+	maxRouteWaypoints       = 20
+	// maxRouteVia is the Routes API's cap on intermediate waypoints.
+	maxRouteVia            = 25
 	earthRadiusMeters      = 6371000.0
 	routePolylinePrecision = 1e5
+	// routePlaceIDPrefix marks a From/To value as a place ID.
+	routePlaceIDPrefix = "placeId:"
 )
 
 const (
@@ -42,17 +50,78 @@ var travelModes = map[string]struct{}{
 	travelModeTransit:    {},
 }
 
+// departureGrace tolerates clock skew and slow typing for "leave now"
+// departure times. The Routes API rejects a past departureTime for driving
+// routes, so buildRouteBody sends one inside the grace as DepartNow.
+const departureGrace = 5 * time.Minute
+
+var transitModes = map[string]struct{}{
+	"BUS": {}, "SUBWAY": {}, "TRAIN": {}, "LIGHT_RAIL": {}, "RAIL": {},
+}
+
+// Traffic routing preferences for DRIVE and TWO_WHEELER routes.
+const (
+	RoutingPreferenceTrafficAware        = "TRAFFIC_AWARE"
+	RoutingPreferenceTrafficAwareOptimal = "TRAFFIC_AWARE_OPTIMAL"
+)
+
+var transitRoutingPreferences = map[string]struct{}{
+	"LESS_WALKING": {}, "FEWER_TRANSFERS": {},
+}
+
+// TransitPreferences shapes TRANSIT routes.
+type TransitPreferences struct {
+	// RoutingPreference is LESS_WALKING or FEWER_TRANSFERS.
+	RoutingPreference string `json:"routing_preference,omitempty"`
+	// AllowedTravelModes limits vehicles to BUS, SUBWAY, TRAIN, LIGHT_RAIL,
+	// or RAIL.
+	AllowedTravelModes []string `json:"allowed_travel_modes,omitempty"`
+}
+
 // RouteRequest describes a query to search along a route.
+// From and To are addresses, or "placeId:<id>" to route from a known place.
 type RouteRequest struct {
-	Query        string  `json:"query"`
-	From         string  `json:"from"`
-	To           string  `json:"to"`
-	Mode         string  `json:"mode,omitempty"`
-	RadiusM      float64 `json:"radius_m,omitempty"`
-	MaxWaypoints int     `json:"max_waypoints,omitempty"`
-	Limit        int     `json:"limit,omitempty"`
-	Language     string  `json:"language,omitempty"`
-	Region       string  `json:"region,omitempty"`
+	Query string `json:"query"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	// Via lists stops between From and To, in order, in the same forms.
+	// At most 25; not supported for TRANSIT.
+	Via          []string `json:"via,omitempty"`
+	Mode         string   `json:"mode,omitempty"`
+	RadiusM      float64  `json:"radius_m,omitempty"`
+	MaxWaypoints int      `json:"max_waypoints,omitempty"`
+	Limit        int      `json:"limit,omitempty"`
+	Language     string   `json:"language,omitempty"`
+	Region       string   `json:"region,omitempty"`
+	// MinRating, OpenNow, and Types are passed to every per-waypoint search.
+	MinRating *float64 `json:"min_rating,omitempty"`
+	OpenNow   *bool    `json:"open_now,omitempty"`
+	Types     []string `json:"types,omitempty"`
+	// Concurrency bounds parallel waypoint searches (default 4).
+	Concurrency int `json:"concurrency,omitempty"`
+	// SampleSeed, when non-zero, shifts interior waypoints along the route
+	// by up to a quarter of their spacing. The same seed always yields the
+	// same waypoints; 0 keeps the evenly spaced default.
+	SampleSeed int64 `json:"sample_seed,omitempty"`
+	// DepartureTime applies to DRIVE and TWO_WHEELER routes, where it makes
+	// the route traffic-aware, and to TRANSIT routes. A zero value means now.
+	// TransitPreferences apply to TRANSIT routes only.
+	DepartureTime      time.Time           `json:"departure_time,omitzero"`
+	TransitPreferences *TransitPreferences `json:"transit_preferences,omitempty"`
+	// DepartNow plans for leaving when the request arrives: driving routes
+	// are traffic-aware without sending a departureTime, which could be past
+	// by then. It takes the same modes as DepartureTime and excludes it.
+	DepartNow bool `json:"depart_now,omitempty"`
+	// RoutingPreference is RoutingPreferenceTrafficAware (the default with a
+	// DepartureTime) or RoutingPreferenceTrafficAwareOptimal, which is slower
+	// to compute. It needs a DRIVE or TWO_WHEELER route with a DepartureTime
+	// or DepartNow.
+	RoutingPreference string `json:"routing_preference,omitempty"`
+	// AvoidTolls, AvoidHighways, and AvoidFerries steer DRIVE and
+	// TWO_WHEELER routes away from those features where possible.
+	AvoidTolls    bool `json:"avoid_tolls,omitempty"`
+	AvoidHighways bool `json:"avoid_highways,omitempty"`
+	AvoidFerries  bool `json:"avoid_ferries,omitempty"`
 }
 
 // RouteResponse contains sampled waypoints with search results.
@@ -62,8 +131,11 @@ type RouteResponse struct {
 
 // RouteWaypoint ties a sampled route location to search results.
 type RouteWaypoint struct {
-	Location LatLng         `json:"location"`
-	Results  []PlaceSummary `json:"results"`
+	Location LatLng `json:"location"`
+	// CumulativeDistanceM is how far along the route polyline the waypoint
+	// sits, in meters from the origin.
+	CumulativeDistanceM float64        `json:"cumulative_distance_m"`
+	Results             []PlaceSummary `json:"results"`
 }
 
 // Route searches for places along a route between two locations.
@@ -78,45 +150,101 @@ func (c *Client) Route(ctx context.Context, req RouteRequest) (RouteResponse, er
 		return RouteResponse{}, err
 	}
 
-	points, err := decodePolyline(polyline)
+	points, err := DecodePolyline(polyline)
 	if err != nil {
 		return RouteResponse{}, err
 	}
 
-	waypoints := sampleWaypoints(points, req.MaxWaypoints)
+	waypoints, distances := sampleWaypoints(points, req.MaxWaypoints, req.SampleSeed)
 	if len(waypoints) == 0 {
 		return RouteResponse{}, errors.New("goplaces: no route waypoints")
 	}
 
-	results := make([]RouteWaypoint, 0, len(waypoints))
-	for _, waypoint := range waypoints {
-		response, err := c.Search(ctx, SearchRequest{
-			Query:    req.Query,
-			Limit:    req.Limit,
-			Language: req.Language,
-			Region:   req.Region,
-			LocationBias: &LocationBias{
-				Lat:     waypoint.Lat,
-				Lng:     waypoint.Lng,
-				RadiusM: req.RadiusM,
-			},
-		})
-		if err != nil {
-			return RouteResponse{}, err
-		}
-		results = append(results, RouteWaypoint{
-			Location: waypoint,
-			Results:  response.Results,
-		})
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	filters := routeFilters(req)
+	// Results land by index so output order never depends on timing.
+	results := make([]RouteWaypoint, len(waypoints))
+	slots := make(chan struct{}, req.Concurrency)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for i, waypoint := range waypoints {
+		wg.Add(1)
+		go func(i int, waypoint LatLng) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			if ctx.Err() != nil {
+				return
+			}
+			response, err := c.Search(ctx, SearchRequest{
+				Query:    req.Query,
+				Filters:  filters,
+				Limit:    req.Limit,
+				Language: req.Language,
+				Region:   req.Region,
+				LocationBias: &LocationBias{
+					Lat:     waypoint.Lat,
+					Lng:     waypoint.Lng,
+					RadiusM: req.RadiusM,
+				},
+			})
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					// Stop the remaining waypoints on the first failure.
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+			results[i] = RouteWaypoint{
+				Location:            waypoint,
+				CumulativeDistanceM: distances[i],
+				Results:             response.Results,
+			}
+		}(i, waypoint)
 	}
+	wg.Wait()
 
+	if firstErr != nil {
+		return RouteResponse{}, firstErr
+	}
+	// Workers skip their search once the caller's context is done, so a
+	// cancellation without any failed search still has to surface here.
+	if err := ctx.Err(); err != nil {
+		return RouteResponse{}, err
+	}
 	return RouteResponse{Waypoints: results}, nil
 }
 
+func routeFilters(req RouteRequest) *Filters {
+	if req.MinRating == nil && req.OpenNow == nil && len(req.Types) == 0 {
+		return nil
+	}
+	return &Filters{
+		Types:     req.Types,
+		MinRating: req.MinRating,
+		OpenNow:   req.OpenNow,
+	}
+}
+
 func applyRouteDefaults(req RouteRequest) RouteRequest {
 	req.Query = strings.TrimSpace(req.Query)
 	req.From = strings.TrimSpace(req.From)
 	req.To = strings.TrimSpace(req.To)
+	if len(req.Via) > 0 {
+		via := make([]string, 0, len(req.Via))
+		for _, stop := range req.Via {
+			via = append(via, strings.TrimSpace(stop))
+		}
+		req.Via = via
+	}
 	req.Mode = strings.ToUpper(strings.TrimSpace(req.Mode))
 	if req.Mode == "" {
 		req.Mode = travelModeDrive
@@ -130,6 +258,17 @@ func applyRouteDefaults(req RouteRequest) RouteRequest {
 	if req.MaxWaypoints == 0 {
 		req.MaxWaypoints = defaultRouteWaypoints
 	}
+	if req.Concurrency == 0 {
+		req.Concurrency = defaultRouteConcurrency
+	}
+	if prefs := req.TransitPreferences; prefs != nil {
+		normalized := TransitPreferences{RoutingPreference: strings.ToUpper(strings.TrimSpace(prefs.RoutingPreference))}
+		for _, mode := range prefs.AllowedTravelModes {
+			normalized.AllowedTravelModes = append(normalized.AllowedTravelModes, strings.ToUpper(strings.TrimSpace(mode)))
+		}
+		req.TransitPreferences = &normalized
+	}
+	req.RoutingPreference = strings.ToUpper(strings.TrimSpace(req.RoutingPreference))
 	return req
 }
 
@@ -143,6 +282,23 @@ func validateRouteRequest(req RouteRequest) error {
 	if req.To == "" {
 		return ValidationError{Field: "to", Message: "required"}
 	}
+	if strings.HasPrefix(req.From, routePlaceIDPrefix) && routePlaceID(req.From) == "" {
+		return ValidationError{Field: "from", Message: "place id required after placeId:"}
+	}
+	if strings.HasPrefix(req.To, routePlaceIDPrefix) && routePlaceID(req.To) == "" {
+		return ValidationError{Field: "to", Message: "place id required after placeId:"}
+	}
+	if len(req.Via) > maxRouteVia {
+		return ValidationError{Field: "via", Message: fmt.Sprintf("at most %d stops", maxRouteVia)}
+	}
+	if len(req.Via) > 0 && req.Mode == travelModeTransit {
+		return ValidationError{Field: "via", Message: "not supported for TRANSIT routes"}
+	}
+	for _, via := range req.Via {
+		if via == "" || (strings.HasPrefix(via, routePlaceIDPrefix) && routePlaceID(via) == "") {
+			return ValidationError{Field: "via", Message: "stops must be non-empty (place id required after placeId:)"}
+		}
+	}
 	if req.Limit < 1 || req.Limit > maxSearchLimit {
 		return ValidationError{Field: "limit", Message: fmt.Sprintf("must be 1-%d", maxSearchLimit)}
 	}
@@ -155,30 +311,155 @@ func validateRouteRequest(req RouteRequest) error {
 	if _, ok := travelModes[req.Mode]; !ok {
 		return ValidationError{Field: "mode", Message: "must be DRIVE, WALK, BICYCLE, TWO_WHEELER, or TRANSIT"}
 	}
+	if req.MinRating != nil && (*req.MinRating < 0 || *req.MinRating > 5) {
+		return ValidationError{Field: "min_rating", Message: "must be 0-5"}
+	}
+	if len(req.Types) > 1 {
+		return ValidationError{Field: "types", Message: "route search accepts a single type"}
+	}
+	if req.Concurrency < 1 || req.Concurrency > maxRouteWaypoints {
+		return ValidationError{Field: "concurrency", Message: fmt.Sprintf("must be 1-%d", maxRouteWaypoints)}
+	}
+	if routeModifiers(req) != nil && req.Mode != travelModeDrive && req.Mode != travelModeTwoWheeler {
+		return ValidationError{Field: "route_modifiers", Message: "avoid tolls/highways/ferries only applies to DRIVE and TWO_WHEELER modes"}
+	}
+	return validateTransit(req)
+}
+
+func validateTransit(req RouteRequest) error {
+	if req.DepartNow {
+		if !req.DepartureTime.IsZero() {
+			return ValidationError{Field: "depart_now", Message: "use either DepartNow or DepartureTime"}
+		}
+		if !takesDepartureTime(req.Mode) {
+			return ValidationError{Field: "depart_now", Message: "only applies to DRIVE, TWO_WHEELER, and TRANSIT modes"}
+		}
+	}
+	if !req.DepartureTime.IsZero() {
+		if !takesDepartureTime(req.Mode) {
+			return ValidationError{Field: "departure_time", Message: "only applies to DRIVE, TWO_WHEELER, and TRANSIT modes"}
+		}
+		if req.DepartureTime.Before(time.Now().Add(-departureGrace)) {
+			return ValidationError{Field: "departure_time", Message: "must not be in the past"}
+		}
+	}
+	if req.RoutingPreference != "" {
+		switch {
+		case req.RoutingPreference != RoutingPreferenceTrafficAware && req.RoutingPreference != RoutingPreferenceTrafficAwareOptimal:
+			return ValidationError{Field: "routing_preference", Message: "must be TRAFFIC_AWARE or TRAFFIC_AWARE_OPTIMAL"}
+		case req.Mode != travelModeDrive && req.Mode != travelModeTwoWheeler:
+			return ValidationError{Field: "routing_preference", Message: "only applies to DRIVE and TWO_WHEELER modes"}
+		case req.DepartureTime.IsZero() && !req.DepartNow:
+			return ValidationError{Field: "routing_preference", Message: "requires a departure time or DepartNow"}
+		}
+	}
+	if req.Mode != travelModeTransit {
+		if req.TransitPreferences != nil {
+			return ValidationError{Field: "transit_preferences", Message: "only applies to TRANSIT mode"}
+		}
+		return nil
+	}
+	prefs := req.TransitPreferences
+	if prefs == nil {
+		return nil
+	}
+	if _, ok := transitRoutingPreferences[prefs.RoutingPreference]; prefs.RoutingPreference != "" && !ok {
+		return ValidationError{Field: "transit_preferences.routing_preference", Message: "must be LESS_WALKING or FEWER_TRANSFERS"}
+	}
+	for _, mode := range prefs.AllowedTravelModes {
+		if _, ok := transitModes[mode]; !ok {
+			return ValidationError{
+				Field:   "transit_preferences.allowed_travel_modes",
+				Message: fmt.Sprintf("unknown mode %q (use BUS, SUBWAY, TRAIN, LIGHT_RAIL, or RAIL)", mode),
+			}
+		}
+	}
 	return nil
 }
 
-func (c *Client) computeRoutePolyline(ctx context.Context, req RouteRequest) (string, error) {
+// takesDepartureTime reports whether the Routes API uses a departure time
+// for mode: traffic for driving modes, schedules for transit.
+func takesDepartureTime(mode string) bool {
+	return mode == travelModeDrive || mode == travelModeTwoWheeler || mode == travelModeTransit
+}
+
+// routeModifiers is the routeModifiers object for the avoidance flags set on
+// req, or nil when none are.
+func routeModifiers(req RouteRequest) map[string]any {
+	modifiers := map[string]any{}
+	if req.AvoidTolls {
+		modifiers["avoidTolls"] = true
+	}
+	if req.AvoidHighways {
+		modifiers["avoidHighways"] = true
+	}
+	if req.AvoidFerries {
+		modifiers["avoidFerries"] = true
+	}
+	if len(modifiers) == 0 {
+		return nil
+	}
+	return modifiers
+}
+
+func buildRouteBody(req RouteRequest) map[string]any {
 	body := map[string]any{
-		"origin": map[string]any{
-			"address": req.From,
-		},
-		"destination": map[string]any{
-			"address": req.To,
-		},
+		"origin":           routeEndpointPayload(req.From),
+		"destination":      routeEndpointPayload(req.To),
 		"travelMode":       req.Mode,
 		"polylineQuality":  "OVERVIEW",
 		"polylineEncoding": "ENCODED_POLYLINE",
 	}
+	if len(req.Via) > 0 {
+		intermediates := make([]map[string]any, 0, len(req.Via))
+		for _, via := range req.Via {
+			intermediates = append(intermediates, routeEndpointPayload(via))
+		}
+		body["intermediates"] = intermediates
+	}
+	if modifiers := routeModifiers(req); modifiers != nil {
+		body["routeModifiers"] = modifiers
+	}
 	if req.Language != "" {
 		body["languageCode"] = req.Language
 	}
 	if req.Region != "" {
 		body["regionCode"] = req.Region
 	}
+	if req.Mode != travelModeTransit && !req.DepartureTime.IsZero() && req.DepartureTime.Before(time.Now()) {
+		// Within departureGrace (validation rejects older times).
+		req.DepartureTime, req.DepartNow = time.Time{}, true
+	}
+	if !req.DepartureTime.IsZero() && takesDepartureTime(req.Mode) {
+		body["departureTime"] = req.DepartureTime.UTC().Format(time.RFC3339)
+	}
+	if takesDepartureTime(req.Mode) && req.Mode != travelModeTransit && (!req.DepartureTime.IsZero() || req.DepartNow) {
+		// The default TRAFFIC_UNAWARE routing ignores the departure time.
+		// Without one (DepartNow), traffic-aware routing plans for leaving now.
+		preference := req.RoutingPreference
+		if preference == "" {
+			preference = RoutingPreferenceTrafficAware
+		}
+		body["routingPreference"] = preference
+	}
+	if req.Mode == travelModeTransit {
+		if prefs := req.TransitPreferences; prefs != nil {
+			transit := map[string]any{}
+			if prefs.RoutingPreference != "" {
+				transit["routingPreference"] = prefs.RoutingPreference
+			}
+			if len(prefs.AllowedTravelModes) > 0 {
+				transit["allowedTravelModes"] = prefs.AllowedTravelModes
+			}
+			body["transitPreferences"] = transit
+		}
+	}
+	return body
+}
 
+func (c *Client) computeRoutePolyline(ctx context.Context, req RouteRequest) (string, error) {
 	endpoint := c.routesBaseURL + routesPath
-	payload, err := c.doRequest(ctx, http.MethodPost, endpoint, body, routesFieldMask)
+	payload, err := c.doRequest(ctx, http.MethodPost, endpoint, buildRouteBody(req), routesFieldMask)
 	if err != nil {
 		return "", err
 	}
@@ -197,7 +478,25 @@ func (c *Client) computeRoutePolyline(ctx context.Context, req RouteRequest) (st
 	return polyline, nil
 }
 
-func decodePolyline(encoded string) ([]LatLng, error) {
+// routeEndpointPayload builds a Routes API waypoint from an address or a
+// "placeId:<id>" token.
+func routeEndpointPayload(value string) map[string]any {
+	if placeID := routePlaceID(value); placeID != "" {
+		return map[string]any{"placeId": placeID}
+	}
+	return map[string]any{"address": value}
+}
+
+func routePlaceID(value string) string {
+	placeID, ok := strings.CutPrefix(value, routePlaceIDPrefix)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(placeID)
+}
+
+// DecodePolyline decodes a Google encoded polyline (precision 1e5).
+func DecodePolyline(encoded string) ([]LatLng, error) {
 	if strings.TrimSpace(encoded) == "" {
 		return nil, errors.New("goplaces: empty polyline")
 	}
@@ -244,36 +543,77 @@ func decodePolyline(encoded string) ([]LatLng, error) {
 	return points, nil
 }
 
-func sampleWaypoints(points []LatLng, maxWaypoints int) []LatLng {
+// EncodePolyline encodes points as a Google encoded polyline (precision 1e5),
+// the inverse of DecodePolyline.
+func EncodePolyline(points []LatLng) string {
+	var out strings.Builder
+	var prevLat, prevLng int
+	for _, point := range points {
+		lat := int(math.Round(point.Lat * routePolylinePrecision))
+		lng := int(math.Round(point.Lng * routePolylinePrecision))
+		writePolylineValue(&out, lat-prevLat)
+		writePolylineValue(&out, lng-prevLng)
+		prevLat, prevLng = lat, lng
+	}
+	return out.String()
+}
+
+func writePolylineValue(out *strings.Builder, delta int) {
+	value := delta << 1
+	if delta < 0 {
+		value = ^value
+	}
+	for value >= 0x20 {
+		out.WriteByte(byte((0x20 | (value & 0x1f)) + 63))
+		value >>= 5
+	}
+	out.WriteByte(byte(value + 63))
+}
+
+// sampleWaypoints picks up to maxWaypoints points along the polyline and
+// returns, for each, its distance from the start along the line.
+func sampleWaypoints(points []LatLng, maxWaypoints int, seed int64) ([]LatLng, []float64) {
 	if len(points) == 0 || maxWaypoints <= 0 {
-		return nil
+		return nil, nil
 	}
 	if len(points) == 1 {
-		return []LatLng{points[0]}
+		return []LatLng{points[0]}, []float64{0}
 	}
 	if maxWaypoints == 1 {
-		return []LatLng{pointAtDistance(points, totalDistance(points)/2)}
-	}
-	if maxWaypoints >= len(points) {
-		return uniqueWaypoints(points)
+		middle := totalDistance(points) / 2
+		return []LatLng{pointAtDistance(points, middle)}, []float64{middle}
 	}
 
 	cumulative := cumulativeDistances(points)
+	if maxWaypoints >= len(points) {
+		return uniqueWaypoints(points, cumulative)
+	}
 	total := cumulative[len(cumulative)-1]
 	if total == 0 {
-		return []LatLng{points[0]}
+		return []LatLng{points[0]}, []float64{0}
 	}
 	spacing := total / float64(maxWaypoints-1)
 
+	var jitter *rand.Rand
+	if seed != 0 {
+		jitter = rand.New(rand.NewPCG(uint64(seed), 0))
+	}
+
 	sampled := make([]LatLng, 0, maxWaypoints)
+	distances := make([]float64, 0, maxWaypoints)
 	for i := 0; i < maxWaypoints; i++ {
 		target := spacing * float64(i)
+		if jitter != nil && i > 0 && i < maxWaypoints-1 {
+			// Endpoints stay fixed; interior targets move ±spacing/4.
+			target += (jitter.Float64() - 0.5) * spacing / 2
+		}
 		point := pointAtCumulative(points, cumulative, target)
 		if len(sampled) == 0 || !samePoint(sampled[len(sampled)-1], point) {
 			sampled = append(sampled, point)
+			distances = append(distances, target)
 		}
 	}
-	return sampled
+	return sampled, distances
 }
 
 func cumulativeDistances(points []LatLng) []float64 {
@@ -330,14 +670,16 @@ func pointAtCumulative(points []LatLng, cumulative []float64, target float64) La
 	}
 }
 
-func uniqueWaypoints(points []LatLng) []LatLng {
+func uniqueWaypoints(points []LatLng, cumulative []float64) ([]LatLng, []float64) {
 	result := make([]LatLng, 0, len(points))
-	for _, point := range points {
+	distances := make([]float64, 0, len(points))
+	for i, point := range points {
 		if len(result) == 0 || !samePoint(result[len(result)-1], point) {
 			result = append(result, point)
+			distances = append(distances, cumulative[i])
 		}
 	}
-	return result
+	return result, distances
 }
 
 func samePoint(a, b LatLng) bool {
@@ -1,11 +1,18 @@
 package goplaces
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestComputeRoutePolyline(t *testing.T) {
@@ -39,12 +46,306 @@ func TestComputeRoutePolyline(t *testing.T) {
 	if gotBody["travelMode"] != travelModeDrive {
 		t.Fatalf("unexpected travelMode: %#v", gotBody["travelMode"])
 	}
+	origin, _ := gotBody["origin"].(map[string]any)
+	if origin["address"] != "Seattle" {
+		t.Fatalf("unexpected origin: %#v", gotBody["origin"])
+	}
+}
+
+func TestComputeRoutePolylineVia(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		_, _ = w.Write([]byte("{\"routes\": [{\"polyline\": {\"encodedPolyline\": \"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", RoutesBaseURL: server.URL})
+	_, err := client.computeRoutePolyline(context.Background(), RouteRequest{
+		From: "Seattle", To: "Portland", Mode: travelModeDrive, Via: []string{"Tacoma", "placeId:olympia"},
+	})
+	if err != nil {
+		t.Fatalf("computeRoutePolyline error: %v", err)
+	}
+	intermediates, _ := gotBody["intermediates"].([]any)
+	if len(intermediates) != 2 {
+		t.Fatalf("expected 2 intermediates, got %#v", gotBody["intermediates"])
+	}
+	first, _ := intermediates[0].(map[string]any)
+	second, _ := intermediates[1].(map[string]any)
+	if first["address"] != "Tacoma" || second["placeId"] != "olympia" {
+		t.Fatalf("unexpected intermediates: %#v", intermediates)
+	}
+}
+
+func TestBuildRouteBodyLeaveNow(t *testing.T) {
+	body := buildRouteBody(RouteRequest{From: "A", To: "B", Mode: travelModeDrive, DepartNow: true})
+	if _, ok := body["departureTime"]; ok || body["routingPreference"] != RoutingPreferenceTrafficAware {
+		t.Fatalf("unexpected leave-now body: %#v", body)
+	}
+	// A driving time that passed within the grace is sent as leaving now.
+	body = buildRouteBody(RouteRequest{From: "A", To: "B", Mode: travelModeDrive, DepartureTime: time.Now().Add(-time.Minute)})
+	if _, ok := body["departureTime"]; ok || body["routingPreference"] != RoutingPreferenceTrafficAware {
+		t.Fatalf("unexpected body for a just-past departure: %#v", body)
+	}
+	body = buildRouteBody(RouteRequest{From: "A", To: "B", Mode: travelModeTransit, DepartNow: true})
+	if _, ok := body["departureTime"]; ok || body["routingPreference"] != nil {
+		t.Fatalf("unexpected transit leave-now body: %#v", body)
+	}
+	if body := buildRouteBody(RouteRequest{From: "A", To: "B", Mode: travelModeDrive}); body["routingPreference"] != nil {
+		t.Fatalf("expected default routing without a departure time: %#v", body)
+	}
+}
+
+func TestComputeRoutePolylineRouteModifiers(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody = nil
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		_, _ = w.Write([]byte("{\"routes\": [{\"polyline\": {\"encodedPolyline\": \"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", RoutesBaseURL: server.URL})
+	req := RouteRequest{From: "Seattle", To: "Portland", Mode: travelModeDrive}
+	if _, err := client.computeRoutePolyline(context.Background(), req); err != nil {
+		t.Fatalf("computeRoutePolyline error: %v", err)
+	}
+	if _, ok := gotBody["routeModifiers"]; ok {
+		t.Fatalf("expected no routeModifiers without avoidance flags: %#v", gotBody["routeModifiers"])
+	}
+
+	req.AvoidTolls = true
+	req.AvoidFerries = true
+	if _, err := client.computeRoutePolyline(context.Background(), req); err != nil {
+		t.Fatalf("computeRoutePolyline error: %v", err)
+	}
+	modifiers, _ := gotBody["routeModifiers"].(map[string]any)
+	if modifiers["avoidTolls"] != true || modifiers["avoidFerries"] != true {
+		t.Fatalf("unexpected routeModifiers: %#v", gotBody["routeModifiers"])
+	}
+	if _, ok := modifiers["avoidHighways"]; ok {
+		t.Fatalf("expected unset flags to be omitted: %#v", modifiers)
+	}
+}
+
+func TestComputeRoutePolylineTransit(t *testing.T) {
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		_, _ = w.Write([]byte("{\"routes\": [{\"polyline\": {\"encodedPolyline\": \"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", RoutesBaseURL: server.URL})
+	depart := time.Date(2030, 5, 1, 8, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	for _, req := range []RouteRequest{
+		{From: "A", To: "B", Mode: travelModeTransit, DepartureTime: depart, TransitPreferences: &TransitPreferences{
+			RoutingPreference:  "FEWER_TRANSFERS",
+			AllowedTravelModes: []string{"TRAIN", "SUBWAY"},
+		}},
+		{From: "A", To: "B", Mode: travelModeTransit},
+	} {
+		if _, err := client.computeRoutePolyline(context.Background(), req); err != nil {
+			t.Fatalf("computeRoutePolyline error: %v", err)
+		}
+	}
+
+	if bodies[0]["departureTime"] != "2030-05-01T06:30:00Z" {
+		t.Fatalf("unexpected departureTime: %#v", bodies[0]["departureTime"])
+	}
+	transit, _ := bodies[0]["transitPreferences"].(map[string]any)
+	modes, _ := transit["allowedTravelModes"].([]any)
+	if transit["routingPreference"] != "FEWER_TRANSFERS" || len(modes) != 2 || modes[0] != "TRAIN" {
+		t.Fatalf("unexpected transitPreferences: %#v", bodies[0]["transitPreferences"])
+	}
+	if _, ok := bodies[1]["departureTime"]; ok {
+		t.Fatalf("expected no departureTime by default: %#v", bodies[1])
+	}
+	if _, ok := bodies[1]["transitPreferences"]; ok {
+		t.Fatalf("expected no transitPreferences by default: %#v", bodies[1])
+	}
+	if _, ok := bodies[0]["routingPreference"]; ok {
+		t.Fatalf("transit routes take no routingPreference: %#v", bodies[0])
+	}
+}
+
+func TestComputeRoutePolylineDriveDeparture(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		_, _ = w.Write([]byte("{\"routes\": [{\"polyline\": {\"encodedPolyline\": \"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", RoutesBaseURL: server.URL})
+	depart := time.Date(2030, 5, 1, 17, 0, 0, 0, time.UTC)
+	_, err := client.computeRoutePolyline(context.Background(), RouteRequest{
+		From: "A", To: "B", Mode: travelModeDrive, DepartureTime: depart,
+	})
+	if err != nil {
+		t.Fatalf("computeRoutePolyline error: %v", err)
+	}
+	if gotBody["departureTime"] != "2030-05-01T17:00:00Z" || gotBody["routingPreference"] != "TRAFFIC_AWARE" {
+		t.Fatalf("expected a traffic-aware departure, got %#v", gotBody)
+	}
+
+	_, err = client.computeRoutePolyline(context.Background(), RouteRequest{
+		From: "A", To: "B", Mode: travelModeTwoWheeler, DepartureTime: depart,
+		RoutingPreference: RoutingPreferenceTrafficAwareOptimal,
+	})
+	if err != nil {
+		t.Fatalf("computeRoutePolyline error: %v", err)
+	}
+	if gotBody["routingPreference"] != RoutingPreferenceTrafficAwareOptimal {
+		t.Fatalf("expected TRAFFIC_AWARE_OPTIMAL, got %#v", gotBody["routingPreference"])
+	}
+}
+
+func TestValidateRouteTransit(t *testing.T) {
+	base := applyRouteDefaults(RouteRequest{Query: "coffee", From: "A", To: "B", Mode: "transit"})
+	tests := []struct {
+		name  string
+		edit  func(*RouteRequest)
+		field string
+	}{
+		{"future departure", func(r *RouteRequest) { r.DepartureTime = time.Now().Add(time.Hour) }, ""},
+		{"within grace", func(r *RouteRequest) { r.DepartureTime = time.Now().Add(-time.Minute) }, ""},
+		{"past departure", func(r *RouteRequest) { r.DepartureTime = time.Now().Add(-time.Hour) }, "departure_time"},
+		{"drive departure", func(r *RouteRequest) {
+			r.Mode = travelModeDrive
+			r.DepartureTime = time.Now().Add(time.Hour)
+		}, ""},
+		{"two-wheeler departure", func(r *RouteRequest) {
+			r.Mode = travelModeTwoWheeler
+			r.DepartureTime = time.Now().Add(time.Hour)
+		}, ""},
+		{"walk departure", func(r *RouteRequest) {
+			r.Mode = travelModeWalk
+			r.DepartureTime = time.Now().Add(time.Hour)
+		}, "departure_time"},
+		{"traffic aware optimal", func(r *RouteRequest) {
+			r.Mode = travelModeDrive
+			r.DepartureTime = time.Now().Add(time.Hour)
+			r.RoutingPreference = "traffic_aware_optimal"
+		}, ""},
+		{"unknown routing preference", func(r *RouteRequest) {
+			r.Mode = travelModeDrive
+			r.DepartureTime = time.Now().Add(time.Hour)
+			r.RoutingPreference = "FASTEST"
+		}, "routing_preference"},
+		{"routing preference without departure", func(r *RouteRequest) {
+			r.Mode = travelModeDrive
+			r.RoutingPreference = RoutingPreferenceTrafficAware
+		}, "routing_preference"},
+		{"routing preference leaving now", func(r *RouteRequest) {
+			r.Mode = travelModeDrive
+			r.RoutingPreference = RoutingPreferenceTrafficAwareOptimal
+			r.DepartNow = true
+		}, ""},
+		{"depart now with departure time", func(r *RouteRequest) {
+			r.DepartNow = true
+			r.DepartureTime = time.Now().Add(time.Hour)
+		}, "depart_now"},
+		{"depart now walking", func(r *RouteRequest) {
+			r.Mode = travelModeWalk
+			r.DepartNow = true
+		}, "depart_now"},
+		{"drive departure within grace", func(r *RouteRequest) {
+			r.Mode = travelModeDrive
+			r.DepartureTime = time.Now().Add(-time.Minute)
+		}, ""},
+		{"drive departure past grace", func(r *RouteRequest) {
+			r.Mode = travelModeDrive
+			r.DepartureTime = time.Now().Add(-time.Hour)
+		}, "departure_time"},
+		{"transit departure within grace", func(r *RouteRequest) {
+			r.DepartureTime = time.Now().Add(-time.Minute)
+		}, ""},
+		{"transit routing preference", func(r *RouteRequest) {
+			r.DepartureTime = time.Now().Add(time.Hour)
+			r.RoutingPreference = RoutingPreferenceTrafficAware
+		}, "routing_preference"},
+		{"past drive departure", func(r *RouteRequest) {
+			r.Mode = travelModeDrive
+			r.DepartureTime = time.Now().Add(-time.Hour)
+		}, "departure_time"},
+		{"two wheeler avoidance", func(r *RouteRequest) {
+			r.Mode = travelModeTwoWheeler
+			r.AvoidHighways = true
+			r.AvoidFerries = true
+		}, ""},
+		{"transit avoidance", func(r *RouteRequest) {
+			r.AvoidTolls = true
+		}, "route_modifiers"},
+		{"drive preferences", func(r *RouteRequest) {
+			r.Mode = travelModeDrive
+			r.TransitPreferences = &TransitPreferences{}
+		}, "transit_preferences"},
+		{"unknown vehicle", func(r *RouteRequest) {
+			r.TransitPreferences = &TransitPreferences{AllowedTravelModes: []string{"FERRY"}}
+		}, "transit_preferences.allowed_travel_modes"},
+		{"unknown routing", func(r *RouteRequest) {
+			r.TransitPreferences = &TransitPreferences{RoutingPreference: "FASTEST"}
+		}, "transit_preferences.routing_preference"},
+	}
+	for _, tc := range tests {
+		req := base
+		tc.edit(&req)
+		err := validateRouteRequest(applyRouteDefaults(req))
+		var validation ValidationError
+		switch {
+		case tc.field == "" && err != nil:
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		case tc.field != "" && (!errors.As(err, &validation) || validation.Field != tc.field):
+			t.Fatalf("%s: expected %s validation error, got %v", tc.name, tc.field, err)
+		}
+	}
+
+	lower := applyRouteDefaults(RouteRequest{Mode: "transit", TransitPreferences: &TransitPreferences{
+		RoutingPreference:  "less_walking",
+		AllowedTravelModes: []string{" bus "},
+	}})
+	if lower.TransitPreferences.RoutingPreference != "LESS_WALKING" || lower.TransitPreferences.AllowedTravelModes[0] != "BUS" {
+		t.Fatalf("expected normalized preferences, got %#v", lower.TransitPreferences)
+	}
+}
+
+func TestComputeRoutePolylinePlaceIDs(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		_, _ = w.Write([]byte("{\"routes\": [{\"polyline\": {\"encodedPolyline\": \"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", RoutesBaseURL: server.URL})
+	_, err := client.computeRoutePolyline(context.Background(), RouteRequest{
+		From: "placeId:ChIJ-origin",
+		To:   "Portland",
+		Mode: travelModeDrive,
+	})
+	if err != nil {
+		t.Fatalf("computeRoutePolyline error: %v", err)
+	}
+	origin, _ := gotBody["origin"].(map[string]any)
+	if len(origin) != 1 || origin["placeId"] != "ChIJ-origin" {
+		t.Fatalf("expected placeId origin, got %#v", gotBody["origin"])
+	}
+	destination, _ := gotBody["destination"].(map[string]any)
+	if destination["address"] != "Portland" {
+		t.Fatalf("unexpected destination: %#v", gotBody["destination"])
+	}
 }
 
 func TestDecodePolyline(t *testing.T) {
-	points, err := decodePolyline("_p~iF~ps|U_ulLnnqC_mqNvxq`@")
+	points, err := DecodePolyline("_p~iF~ps|U_ulLnnqC_mqNvxq`@")
 	if err != nil {
-		t.Fatalf("decodePolyline error: %v", err)
+		t.Fatalf("DecodePolyline error: %v", err)
 	}
 	if len(points) != 3 {
 		t.Fatalf("expected 3 points, got %d", len(points))
@@ -55,33 +356,82 @@ func TestDecodePolyline(t *testing.T) {
 }
 
 func TestDecodePolylineInvalid(t *testing.T) {
-	_, err := decodePolyline("")
+	_, err := DecodePolyline("")
 	if err == nil {
 		t.Fatalf("expected decode error")
 	}
 }
 
 func TestDecodePolylineMalformed(t *testing.T) {
-	_, err := decodePolyline("abc")
+	_, err := DecodePolyline("abc")
 	if err == nil {
 		t.Fatalf("expected malformed error")
 	}
 }
 
+func TestEncodePolylineRoundTrip(t *testing.T) {
+	const encoded = "_p~iF~ps|U_ulLnnqC_mqNvxq`@"
+	points, err := DecodePolyline(encoded)
+	if err != nil {
+		t.Fatalf("DecodePolyline error: %v", err)
+	}
+	if got := EncodePolyline(points); got != encoded {
+		t.Fatalf("expected %q, got %q", encoded, got)
+	}
+	if got := EncodePolyline(nil); got != "" {
+		t.Fatalf("expected empty polyline, got %q", got)
+	}
+}
+
 func TestSampleWaypoints(t *testing.T) {
 	points := []LatLng{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 0, Lng: 2}}
-	waypoints := sampleWaypoints(points, 2)
+	waypoints, distances := sampleWaypoints(points, 2, 0)
 	if len(waypoints) != 2 {
 		t.Fatalf("expected 2 waypoints, got %d", len(waypoints))
 	}
 	if waypoints[0].Lng != 0 || waypoints[1].Lng != 2 {
 		t.Fatalf("unexpected waypoints: %#v", waypoints)
 	}
+	if len(distances) != 2 || distances[0] != 0 || math.Abs(distances[1]-totalDistance(points)) > 1e-6 {
+		t.Fatalf("unexpected distances: %v", distances)
+	}
+
+	waypoints, distances = sampleWaypoints(points, 1, 0)
+	if len(waypoints) != 1 || math.Abs(distances[0]-totalDistance(points)/2) > 1e-6 {
+		t.Fatalf("expected the midpoint distance, got %v", distances)
+	}
+}
+
+func TestSampleWaypointsSeedReproducible(t *testing.T) {
+	points := []LatLng{{Lat: 47, Lng: -122}, {Lat: 47.5, Lng: -122}, {Lat: 48, Lng: -122}, {Lat: 48.5, Lng: -122}}
+	even, _ := sampleWaypoints(points, 3, 0)
+	first, firstDistances := sampleWaypoints(points, 3, 42)
+	second, _ := sampleWaypoints(points, 3, 42)
+	other, _ := sampleWaypoints(points, 3, 7)
+
+	if len(first) != 3 || len(second) != 3 {
+		t.Fatalf("expected 3 waypoints, got %d and %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("same seed produced different waypoint %d: %#v vs %#v", i, first[i], second[i])
+		}
+	}
+	if first[0] != even[0] || first[2] != even[2] {
+		t.Fatalf("expected fixed endpoints, got %#v vs %#v", first, even)
+	}
+	if first[1] == even[1] || first[1] == other[1] {
+		t.Fatalf("expected seeded jitter on the middle waypoint: even=%#v seed42=%#v seed7=%#v", even[1], first[1], other[1])
+	}
+	// The jittered waypoint's distance matches where it actually sits.
+	if got := pointAtDistance(points, firstDistances[1]); !samePoint(got, first[1]) {
+		t.Fatalf("distance %f does not locate %#v (got %#v)", firstDistances[1], first[1], got)
+	}
 }
 
 func TestSampleWaypointsSingle(t *testing.T) {
 	points := []LatLng{{Lat: 1, Lng: 1}, {Lat: 2, Lng: 2}}
-	waypoints := sampleWaypoints(points, 1)
+	waypoints, _ := sampleWaypoints(points, 1, 0)
 	if len(waypoints) != 1 {
 		t.Fatalf("expected 1 waypoint")
 	}
@@ -89,7 +439,7 @@ func TestSampleWaypointsSingle(t *testing.T) {
 
 func TestSampleWaypointsSinglePoint(t *testing.T) {
 	points := []LatLng{{Lat: 1, Lng: 1}}
-	waypoints := sampleWaypoints(points, 5)
+	waypoints, _ := sampleWaypoints(points, 5, 0)
 	if len(waypoints) != 1 {
 		t.Fatalf("expected 1 waypoint")
 	}
@@ -97,7 +447,7 @@ func TestSampleWaypointsSinglePoint(t *testing.T) {
 
 func TestSampleWaypointsZeroTotal(t *testing.T) {
 	points := []LatLng{{Lat: 1, Lng: 1}, {Lat: 1, Lng: 1}}
-	waypoints := sampleWaypoints(points, 3)
+	waypoints, _ := sampleWaypoints(points, 3, 0)
 	if len(waypoints) != 1 {
 		t.Fatalf("expected 1 waypoint")
 	}
@@ -108,7 +458,7 @@ func TestSampleWaypointsZeroTotal(t *testing.T) {
 
 func TestSampleWaypointsMaxExceedsPoints(t *testing.T) {
 	points := []LatLng{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}}
-	waypoints := sampleWaypoints(points, 5)
+	waypoints, _ := sampleWaypoints(points, 5, 0)
 	if len(waypoints) != 2 {
 		t.Fatalf("expected 2 waypoints, got %d", len(waypoints))
 	}
@@ -127,10 +477,13 @@ func TestPointAtDistanceBounds(t *testing.T) {
 
 func TestUniqueWaypoints(t *testing.T) {
 	points := []LatLng{{Lat: 1, Lng: 1}, {Lat: 1, Lng: 1}, {Lat: 2, Lng: 2}}
-	unique := uniqueWaypoints(points)
+	unique, distances := uniqueWaypoints(points, cumulativeDistances(points))
 	if len(unique) != 2 {
 		t.Fatalf("expected 2 unique points, got %d", len(unique))
 	}
+	if distances[0] != 0 || distances[1] <= 0 {
+		t.Fatalf("unexpected distances: %v", distances)
+	}
 }
 
 func TestDistanceMeters(t *testing.T) {
@@ -170,6 +523,55 @@ func TestValidateRouteRequest(t *testing.T) {
 	if err == nil {
 		t.Fatalf("expected mode error")
 	}
+	err = validateRouteRequest(RouteRequest{
+		Query:        "coffee",
+		From:         "A",
+		To:           "placeId: ",
+		Mode:         travelModeDrive,
+		Limit:        1,
+		RadiusM:      1,
+		MaxWaypoints: 1,
+	})
+	var validation ValidationError
+	if !errors.As(err, &validation) || validation.Field != "to" {
+		t.Fatalf("expected to validation error for empty place id, got %v", err)
+	}
+}
+
+func TestValidateRouteVia(t *testing.T) {
+	base := applyRouteDefaults(RouteRequest{Query: "coffee", From: "A", To: "B"})
+	tooMany := make([]string, maxRouteVia+1)
+	for i := range tooMany {
+		tooMany[i] = "Stop"
+	}
+	tests := []struct {
+		name  string
+		via   []string
+		mode  string
+		field string
+	}{
+		{"two stops", []string{"Olympia", "placeId:abc"}, "", ""},
+		{"cap", tooMany[:maxRouteVia], "", ""},
+		{"over cap", tooMany, "", "via"},
+		{"blank stop", []string{" "}, "", "via"},
+		{"empty place id", []string{"placeId:"}, "", "via"},
+		{"transit", []string{"Olympia"}, "transit", "via"},
+	}
+	for _, tc := range tests {
+		req := base
+		req.Via = tc.via
+		if tc.mode != "" {
+			req.Mode = tc.mode
+		}
+		err := validateRouteRequest(applyRouteDefaults(req))
+		var validation ValidationError
+		switch {
+		case tc.field == "" && err != nil:
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		case tc.field != "" && (!errors.As(err, &validation) || validation.Field != tc.field):
+			t.Fatalf("%s: expected %s validation error, got %v", tc.name, tc.field, err)
+		}
+	}
 }
 
 func TestValidateRouteRequestBounds(t *testing.T) {
@@ -216,6 +618,9 @@ func TestApplyRouteDefaultsEmpty(t *testing.T) {
 	if req.MaxWaypoints != defaultRouteWaypoints {
 		t.Fatalf("expected default waypoints")
 	}
+	if req.Concurrency != defaultRouteConcurrency {
+		t.Fatalf("expected default concurrency")
+	}
 }
 
 func TestComputeRoutePolylineErrors(t *testing.T) {
@@ -258,13 +663,13 @@ func TestComputeRoutePolylineInvalidJSON(t *testing.T) {
 }
 
 func TestRouteEndToEnd(t *testing.T) {
-	searchCalls := 0
+	var searchCalls atomic.Int32
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case routesPath:
 			_, _ = w.Write([]byte("{\"routes\": [{\"polyline\": {\"encodedPolyline\": \"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
 		case "/places:searchText":
-			searchCalls++
+			searchCalls.Add(1)
 			_, _ = w.Write([]byte(`{"places":[{"id":"abc","displayName":{"text":"Cafe"}}]}`))
 		default:
 			t.Fatalf("unexpected path: %s", r.URL.Path)
@@ -284,9 +689,13 @@ func TestRouteEndToEnd(t *testing.T) {
 	if len(response.Waypoints) == 0 {
 		t.Fatalf("expected waypoints")
 	}
-	if searchCalls == 0 {
+	if searchCalls.Load() == 0 {
 		t.Fatalf("expected search calls")
 	}
+	last := response.Waypoints[len(response.Waypoints)-1]
+	if response.Waypoints[0].CumulativeDistanceM != 0 || last.CumulativeDistanceM <= 0 {
+		t.Fatalf("expected distances from 0 along the route, got %#v", response.Waypoints)
+	}
 }
 
 func TestRouteSearchError(t *testing.T) {
@@ -314,6 +723,57 @@ func TestRouteSearchError(t *testing.T) {
 	}
 }
 
+// cancelAfterTransport cancels a context once a response for path has been
+// fully read, so the caller sees a successful call and a done context.
+type cancelAfterTransport struct {
+	path   string
+	cancel context.CancelFunc
+}
+
+func (t cancelAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	response, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil || req.URL.Path != t.path {
+		return response, err
+	}
+	payload, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(payload))
+	t.cancel()
+	return response, nil
+}
+
+func TestRouteCancelledBeforeWaypointSearches(t *testing.T) {
+	searches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == routesPath {
+			_, _ = w.Write([]byte("{\"routes\": [{\"polyline\": {\"encodedPolyline\": \"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+			return
+		}
+		searches++
+		_, _ = w.Write([]byte(`{"places": []}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := NewClient(Options{
+		APIKey:        "test-key",
+		BaseURL:       server.URL,
+		RoutesBaseURL: server.URL,
+		Transport:     cancelAfterTransport{path: routesPath, cancel: cancel},
+	})
+	response, err := client.Route(ctx, RouteRequest{Query: "coffee", From: "Seattle", To: "Portland"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v (response=%+v)", err, response)
+	}
+	if searches != 0 {
+		t.Fatalf("expected no waypoint searches after cancel, got %d", searches)
+	}
+}
+
 func TestRouteComputeRouteError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != routesPath {
@@ -333,3 +793,168 @@ func TestRouteComputeRouteError(t *testing.T) {
 		t.Fatalf("expected route error")
 	}
 }
+
+func TestRoutePassesFiltersToWaypointSearch(t *testing.T) {
+	var searchCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case routesPath:
+			_, _ = w.Write([]byte("{\"routes\": [{\"polyline\": {\"encodedPolyline\": \"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+		case "/places:searchText":
+			searchCalls.Add(1)
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body["minRating"] != 4.2 {
+				t.Fatalf("expected minRating 4.2, got %#v", body["minRating"])
+			}
+			if body["openNow"] != true {
+				t.Fatalf("expected openNow true, got %#v", body["openNow"])
+			}
+			_, _ = w.Write([]byte(`{"places":[]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	minRating := 4.2
+	openNow := true
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, RoutesBaseURL: server.URL})
+	_, err := client.Route(context.Background(), RouteRequest{
+		Query:     "coffee",
+		From:      "Seattle",
+		To:        "Portland",
+		MinRating: &minRating,
+		OpenNow:   &openNow,
+	})
+	if err != nil {
+		t.Fatalf("route error: %v", err)
+	}
+	if searchCalls.Load() == 0 {
+		t.Fatalf("expected search calls")
+	}
+}
+
+func TestRouteTypesValidation(t *testing.T) {
+	err := validateRouteRequest(applyRouteDefaults(RouteRequest{Query: "fuel", From: "A", To: "B", Types: []string{"gas_station", "car_wash"}}))
+	var validationErr ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "types" {
+		t.Fatalf("expected types validation error, got %v", err)
+	}
+}
+
+func TestRouteMinRatingValidation(t *testing.T) {
+	minRating := 6.0
+	err := validateRouteRequest(applyRouteDefaults(RouteRequest{Query: "coffee", From: "A", To: "B", MinRating: &minRating}))
+	var validationErr ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "min_rating" {
+		t.Fatalf("expected min_rating validation error, got %v", err)
+	}
+}
+
+func TestRoutePassesTypeToWaypointSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case routesPath:
+			_, _ = w.Write([]byte("{\"routes\": [{\"polyline\": {\"encodedPolyline\": \"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+		case "/places:searchText":
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body["includedType"] != "gas_station" {
+				t.Fatalf("expected includedType gas_station, got %#v", body["includedType"])
+			}
+			if _, ok := body["minRating"]; ok {
+				t.Fatalf("unexpected minRating in body: %#v", body)
+			}
+			_, _ = w.Write([]byte(`{"places":[]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, RoutesBaseURL: server.URL})
+	_, err := client.Route(context.Background(), RouteRequest{
+		Query: "fuel",
+		From:  "Seattle",
+		To:    "Portland",
+		Types: []string{"gas_station"},
+	})
+	if err != nil {
+		t.Fatalf("route error: %v", err)
+	}
+}
+
+func TestRouteConcurrentWaypointSearches(t *testing.T) {
+	line := make([]LatLng, 0, 10)
+	for i := range 10 {
+		line = append(line, LatLng{Lat: 47 + float64(i)*0.1, Lng: -122})
+	}
+	polyline, err := json.Marshal(EncodePolyline(line))
+	if err != nil {
+		t.Fatalf("marshal polyline: %v", err)
+	}
+
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case routesPath:
+			_, _ = w.Write([]byte(`{"routes": [{"polyline": {"encodedPolyline": ` + string(polyline) + `}}]}`))
+		case "/places:searchText":
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				seen := peak.Load()
+				if current <= seen || peak.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+			var body struct {
+				LocationBias struct {
+					Circle struct {
+						Center struct {
+							Latitude float64 `json:"latitude"`
+						} `json:"center"`
+					} `json:"circle"`
+				} `json:"locationBias"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			time.Sleep(20 * time.Millisecond)
+			id := strconv.FormatFloat(body.LocationBias.Circle.Center.Latitude, 'f', 6, 64)
+			_, _ = w.Write([]byte(`{"places":[{"id":"` + id + `"}]}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, RoutesBaseURL: server.URL})
+	response, err := client.Route(context.Background(), RouteRequest{
+		Query:        "coffee",
+		From:         "Seattle",
+		To:           "Portland",
+		MaxWaypoints: 8,
+		Concurrency:  3,
+	})
+	if err != nil {
+		t.Fatalf("route error: %v", err)
+	}
+	if got := peak.Load(); got < 2 || got > 3 {
+		t.Fatalf("expected 2-3 concurrent searches, peak was %d", got)
+	}
+	if len(response.Waypoints) != 8 {
+		t.Fatalf("expected 8 waypoints, got %d", len(response.Waypoints))
+	}
+	for i, waypoint := range response.Waypoints {
+		want := strconv.FormatFloat(waypoint.Location.Lat, 'f', 6, 64)
+		if len(waypoint.Results) != 1 || waypoint.Results[0].PlaceID != want {
+			t.Fatalf("waypoint %d results out of order: %#v", i, waypoint.Results)
+		}
+	}
+}
@@ -8,7 +8,24 @@ import (
 	"strings"
 )
 
-const searchFieldMask = "places.id,places.displayName,places.formattedAddress,places.location,places.rating,places.priceLevel,places.types,places.currentOpeningHours,nextPageToken"
+const searchFieldMask = "places.id,places.displayName,places.formattedAddress,places.location,places.rating,places.userRatingCount,places.priceLevel,places.priceRange,places.types,places.currentOpeningHours,places.businessStatus,nextPageToken"
+
+// RankPreference values. Text search accepts RELEVANCE and DISTANCE;
+// nearby search accepts POPULARITY and DISTANCE.
+const (
+	RankPreferenceRelevance  = "RELEVANCE"
+	RankPreferenceDistance   = "DISTANCE"
+	RankPreferencePopularity = "POPULARITY"
+)
+
+// KeywordMode values for SearchRequest.KeywordMode.
+const (
+	// KeywordModeAppend (the default) appends Filters.Keyword to the query.
+	KeywordModeAppend = "APPEND"
+	// KeywordModeSeparate keeps Filters.Keyword out of the query; text
+	// search has no keyword parameter, so it is not sent at all.
+	KeywordModeSeparate = "SEPARATE"
+)
 
 // Search performs a text search with optional filters.
 func (c *Client) Search(ctx context.Context, req SearchRequest) (SearchResponse, error) {
@@ -16,13 +33,39 @@ func (c *Client) Search(ctx context.Context, req SearchRequest) (SearchResponse,
 	if err := validateSearchRequest(req); err != nil {
 		return SearchResponse{}, err
 	}
-
-	body := buildSearchBody(req)
-	endpoint, err := c.buildURL("/places:searchText", nil)
-	if err != nil {
+	if err := validateFields(req.Fields, searchFields); err != nil {
 		return SearchResponse{}, err
 	}
-	payload, err := c.doRequest(ctx, http.MethodPost, endpoint, body, searchFieldMask)
+	if req.PageSize > 0 && req.PageSize < req.Limit {
+		return c.searchPaged(ctx, req)
+	}
+	return c.searchPage(ctx, req)
+}
+
+// searchPaged issues PageSize-sized calls until Limit results are gathered.
+func (c *Client) searchPaged(ctx context.Context, req SearchRequest) (SearchResponse, error) {
+	var merged SearchResponse
+	page := req
+	for {
+		response, err := c.searchPage(ctx, page)
+		if err != nil {
+			return SearchResponse{}, err
+		}
+		merged.Results = append(merged.Results, response.Results...)
+		merged.NextPageToken = response.NextPageToken
+		if len(merged.Results) >= req.Limit || response.NextPageToken == "" {
+			break
+		}
+		page.PageToken = response.NextPageToken
+	}
+	if len(merged.Results) > req.Limit {
+		merged.Results = merged.Results[:req.Limit]
+	}
+	return merged, nil
+}
+
+func (c *Client) searchPage(ctx context.Context, req SearchRequest) (SearchResponse, error) {
+	payload, err := c.searchPayload(ctx, req)
 	if err != nil {
 		return SearchResponse{}, err
 	}
@@ -43,16 +86,47 @@ func (c *Client) Search(ctx context.Context, req SearchRequest) (SearchResponse,
 	}, nil
 }
 
+func (c *Client) searchPayload(ctx context.Context, req SearchRequest) ([]byte, error) {
+	language, region, err := c.locale(req.Language, req.Region)
+	if err != nil {
+		return nil, err
+	}
+	req.Language, req.Region = language, region
+	endpoint, err := c.buildURL("/places:searchText", nil)
+	if err != nil {
+		return nil, err
+	}
+	fieldMask := searchFieldMaskForRequest(req)
+	var key string
+	if c.cacheSearches {
+		key = c.searchCacheKey(req, fieldMask)
+	}
+	if payload, ok := c.cachedBody(key); ok {
+		return payload, nil
+	}
+	payload, err := c.doClampedRequest(ctx, http.MethodPost, endpoint, buildSearchBody(req), fieldMask, "pageSize", maxSearchLimit)
+	if err != nil {
+		return nil, err
+	}
+	c.cacheStore(key, payload, "")
+	return payload, nil
+}
+
 func buildSearchBody(req SearchRequest) map[string]any {
 	textQuery := req.Query
-	if req.Filters != nil && strings.TrimSpace(req.Filters.Keyword) != "" {
+	if req.Filters != nil && strings.TrimSpace(req.Filters.Keyword) != "" && req.KeywordMode != KeywordModeSeparate {
 		// Google expects a single text query; append keywords here.
 		textQuery = strings.TrimSpace(textQuery + " " + req.Filters.Keyword)
 	}
 
+	pageSize := req.Limit
+	if req.PageSize > 0 && req.PageSize < pageSize {
+		pageSize = req.PageSize
+	}
+
 	body := map[string]any{
 		"textQuery": textQuery,
-		"pageSize":  req.Limit,
+		"pageSize":  pageSize,
 	}
 	if strings.TrimSpace(req.Language) != "" {
 		body["languageCode"] = strings.TrimSpace(req.Language)
@@ -64,16 +138,22 @@ func buildSearchBody(req SearchRequest) map[string]any {
 	if req.PageToken != "" {
 		body["pageToken"] = req.PageToken
 	}
+	if req.RankPreference != "" {
+		body["rankPreference"] = req.RankPreference
+	}
+	if req.StrictTypeFiltering {
+		body["strictTypeFiltering"] = true
+	}
 
 	if req.LocationBias != nil {
-		// Places API expects a circular bias object.
-		body["locationBias"] = circlePayload(req.LocationBias)
+		// Places API expects a circle or rectangle bias object.
+		body["locationBias"] = locationBiasPayload(req.LocationBias)
 	}
 
 	if req.Filters != nil {
 		filters := req.Filters
 		if len(filters.Types) > 0 {
-			// API accepts a single includedType; use the first value.
+			// Text search takes a single includedType; validation rejects more.
 			body["includedType"] = filters.Types[0]
 		}
 		if filters.OpenNow != nil {
@@ -100,14 +180,18 @@ func buildSearchBody(req SearchRequest) map[string]any {
 
 func mapPlaceSummary(place placeItem) PlaceSummary {
 	return PlaceSummary{
-		PlaceID:    place.ID,
-		Name:       displayName(place.DisplayName),
-		Address:    place.FormattedAddress,
-		Location:   mapLatLng(place.Location),
-		Rating:     place.Rating,
-		PriceLevel: mapPriceLevel(place.PriceLevel),
-		Types:      place.Types,
-		OpenNow:    openNow(place.CurrentOpeningHours),
+		PlaceID:         place.ID,
+		Name:            displayName(place.DisplayName),
+		NameLanguage:    displayNameLanguage(place.DisplayName),
+		Address:         place.FormattedAddress,
+		Location:        mapLatLng(place.Location),
+		Rating:          place.Rating,
+		UserRatingCount: place.UserRatingCount,
+		PriceLevel:      mapPriceLevel(place.PriceLevel),
+		PriceRange:      mapPriceRange(place.PriceRange),
+		Types:           place.Types,
+		OpenNow:         openNow(place.CurrentOpeningHours),
+		BusinessStatus:  place.BusinessStatus,
 	}
 }
 
@@ -115,9 +199,18 @@ func applySearchDefaults(req SearchRequest) SearchRequest {
 	if req.Limit == 0 {
 		req.Limit = defaultSearchLimit
 	}
+	req.RankPreference = strings.ToUpper(strings.TrimSpace(req.RankPreference))
+	req.KeywordMode = strings.ToUpper(strings.TrimSpace(req.KeywordMode))
 	return req
 }
 
+func searchFieldMaskForRequest(req SearchRequest) string {
+	if len(req.Fields) == 0 {
+		return searchFieldMask
+	}
+	return customFieldMask(req.Fields, "places.") + ",nextPageToken"
+}
+
 func validateSearchRequest(req SearchRequest) error {
 	if strings.TrimSpace(req.Query) == "" {
 		return ValidationError{Field: "query", Message: "required"}
@@ -125,6 +218,9 @@ func validateSearchRequest(req SearchRequest) error {
 	if req.Limit < 1 || req.Limit > maxSearchLimit {
 		return ValidationError{Field: "limit", Message: fmt.Sprintf("must be 1-%d", maxSearchLimit)}
 	}
+	if req.PageSize < 0 || req.PageSize > maxSearchLimit {
+		return ValidationError{Field: "page_size", Message: fmt.Sprintf("must be 0-%d", maxSearchLimit)}
+	}
 
 	if req.Filters != nil {
 		if req.Filters.MinRating != nil {
@@ -137,6 +233,9 @@ func validateSearchRequest(req SearchRequest) error {
 				return ValidationError{Field: "filters.price_levels", Message: "must be 0-4"}
 			}
 		}
+		if len(req.Filters.Types) > 1 {
+			return ValidationError{Field: "filters.types", Message: "text search accepts a single type; use nearby search for several"}
+		}
 	}
 
 	if req.LocationBias != nil {
@@ -145,5 +244,20 @@ func validateSearchRequest(req SearchRequest) error {
 		}
 	}
 
+	switch req.RankPreference {
+	case "", RankPreferenceRelevance:
+	case RankPreferenceDistance:
+		if req.LocationBias == nil {
+			return ValidationError{Field: "rank_preference", Message: "DISTANCE requires location_bias"}
+		}
+	default:
+		return ValidationError{Field: "rank_preference", Message: "must be RELEVANCE or DISTANCE"}
+	}
+	switch req.KeywordMode {
+	case "", KeywordModeAppend, KeywordModeSeparate:
+	default:
+		return ValidationError{Field: "keyword_mode", Message: "must be APPEND or SEPARATE"}
+	}
+
 	return nil
 }
@@ -0,0 +1,92 @@
+package goplaces
+
+import (
+	"math"
+	"sort"
+)
+
+// SortByRating orders places by rating (highest first), breaking ties by
+// review count so a 4.8 with 2,000 reviews outranks a 4.8 with 3. Unrated
+// places sort last; the sort is stable otherwise.
+func SortByRating(places []PlaceSummary) {
+	sort.SliceStable(places, func(i, j int) bool {
+		return ratingLess(places[j], places[i])
+	})
+}
+
+// ratingLess reports whether a ranks below b by rating, then review count.
+func ratingLess(a, b PlaceSummary) bool {
+	if a.Rating == nil || b.Rating == nil {
+		return a.Rating == nil && b.Rating != nil
+	}
+	if *a.Rating != *b.Rating {
+		return *a.Rating < *b.Rating
+	}
+	return ratingCount(a) < ratingCount(b)
+}
+
+// SortByRatingCount orders places by review count (most first), breaking
+// ties by rating. The sort is stable otherwise.
+func SortByRatingCount(places []PlaceSummary) {
+	sort.SliceStable(places, func(i, j int) bool {
+		a, b := places[i], places[j]
+		if countA, countB := ratingCount(a), ratingCount(b); countA != countB {
+			return countA > countB
+		}
+		return ratingLess(b, a)
+	})
+}
+
+// SortByDistance orders places nearest to center first (great-circle
+// distance). Places without a location sort last.
+func SortByDistance(places []PlaceSummary, center LatLng) {
+	sort.SliceStable(places, func(i, j int) bool {
+		a, b := places[i].Location, places[j].Location
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return distanceMeters(center, *a) < distanceMeters(center, *b)
+	})
+}
+
+func ratingCount(place PlaceSummary) int {
+	if place.UserRatingCount == nil {
+		return 0
+	}
+	return *place.UserRatingCount
+}
+
+// Score ranks a place for "top pick" decisions: rating × ln(1 + review
+// count), so well-reviewed places beat a handful of perfect scores. Unrated
+// places score 0.
+func (p PlaceSummary) Score() float64 {
+	if p.Rating == nil {
+		return 0
+	}
+	return *p.Rating * math.Log1p(float64(ratingCount(p)))
+}
+
+// BestResult picks the highest-scoring place, breaking ties by name and then
+// place ID so the choice is deterministic. It returns false for no results.
+func BestResult(results []PlaceSummary) (PlaceSummary, bool) {
+	if len(results) == 0 {
+		return PlaceSummary{}, false
+	}
+	best := results[0]
+	for _, place := range results[1:] {
+		if betterPick(place, best) {
+			best = place
+		}
+	}
+	return best, true
+}
+
+func betterPick(a, b PlaceSummary) bool {
+	if scoreA, scoreB := a.Score(), b.Score(); scoreA != scoreB {
+		return scoreA > scoreB
+	}
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	return a.PlaceID < b.PlaceID
+}
@@ -0,0 +1,100 @@
+package goplaces
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSortByRatingTiebreaksOnReviewCount(t *testing.T) {
+	rating := func(v float64) *float64 { return &v }
+	count := func(v int) *int { return &v }
+	places := []PlaceSummary{
+		{PlaceID: "few", Rating: rating(4.8), UserRatingCount: count(3)},
+		{PlaceID: "unrated"},
+		{PlaceID: "lower", Rating: rating(4.2), UserRatingCount: count(5000)},
+		{PlaceID: "many", Rating: rating(4.8), UserRatingCount: count(2000)},
+		{PlaceID: "nocount", Rating: rating(4.8)},
+	}
+	SortByRating(places)
+
+	want := []string{"many", "few", "nocount", "lower", "unrated"}
+	for i, id := range want {
+		if places[i].PlaceID != id {
+			t.Fatalf("position %d: expected %s, got %s", i, id, places[i].PlaceID)
+		}
+	}
+}
+
+func TestSortByRatingCount(t *testing.T) {
+	rating := func(v float64) *float64 { return &v }
+	count := func(v int) *int { return &v }
+	places := []PlaceSummary{
+		{PlaceID: "none"},
+		{PlaceID: "mid", Rating: rating(4.0), UserRatingCount: count(50)},
+		{PlaceID: "top-low", Rating: rating(3.9), UserRatingCount: count(900)},
+		{PlaceID: "top-high", Rating: rating(4.6), UserRatingCount: count(900)},
+	}
+	SortByRatingCount(places)
+
+	want := []string{"top-high", "top-low", "mid", "none"}
+	for i, id := range want {
+		if places[i].PlaceID != id {
+			t.Fatalf("position %d: expected %s, got %s", i, id, places[i].PlaceID)
+		}
+	}
+}
+
+func TestSortByDistance(t *testing.T) {
+	places := []PlaceSummary{
+		{PlaceID: "far", Location: &LatLng{Lat: 48, Lng: 2}},
+		{PlaceID: "unknown"},
+		{PlaceID: "near", Location: &LatLng{Lat: 47.01, Lng: 2}},
+		{PlaceID: "mid", Location: &LatLng{Lat: 47.5, Lng: 2}},
+	}
+	SortByDistance(places, LatLng{Lat: 47, Lng: 2})
+
+	want := []string{"near", "mid", "far", "unknown"}
+	for i, id := range want {
+		if places[i].PlaceID != id {
+			t.Fatalf("position %d: expected %s, got %s", i, id, places[i].PlaceID)
+		}
+	}
+}
+
+func TestPlaceSummaryScore(t *testing.T) {
+	rating := 4.0
+	count := 99
+	if got := (PlaceSummary{}).Score(); got != 0 {
+		t.Fatalf("expected 0 for unrated place, got %v", got)
+	}
+	want := 4.0 * math.Log(100)
+	if got := (PlaceSummary{Rating: &rating, UserRatingCount: &count}).Score(); math.Abs(got-want) > 1e-9 {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestBestResult(t *testing.T) {
+	rating := func(v float64) *float64 { return &v }
+	count := func(v int) *int { return &v }
+
+	if _, ok := BestResult(nil); ok {
+		t.Fatalf("expected no pick for empty results")
+	}
+
+	best, ok := BestResult([]PlaceSummary{
+		{PlaceID: "perfect", Name: "Tiny", Rating: rating(5.0), UserRatingCount: count(2)},
+		{PlaceID: "popular", Name: "Busy", Rating: rating(4.5), UserRatingCount: count(3000)},
+		{PlaceID: "unrated", Name: "Unknown"},
+	})
+	if !ok || best.PlaceID != "popular" {
+		t.Fatalf("expected popular pick, got %#v", best)
+	}
+
+	best, _ = BestResult([]PlaceSummary{
+		{PlaceID: "b", Name: "Zeta", Rating: rating(4.0), UserRatingCount: count(10)},
+		{PlaceID: "a", Name: "Alpha", Rating: rating(4.0), UserRatingCount: count(10)},
+	})
+	if best.PlaceID != "a" {
+		t.Fatalf("expected name tiebreak to pick Alpha, got %#v", best)
+	}
+}
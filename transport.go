@@ -0,0 +1,23 @@
+package goplaces
+
+import (
+	"net/http"
+	"time"
+)
+
+// defaultMaxIdleConnsPerHost keeps enough warm connections for the fan-out in
+// Route and NearbyGrid; net/http's default of 2 forces extra TLS handshakes.
+const defaultMaxIdleConnsPerHost = 16
+
+// DefaultTransport returns the transport NewClient uses when neither
+// Options.HTTPClient nor Options.Transport is set. It is a fresh clone of
+// http.DefaultTransport with a larger per-host idle pool, so callers can tune
+// it further (e.g. DisableKeepAlives) and pass it back via Options.Transport.
+func DefaultTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ForceAttemptHTTP2 = true
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	transport.IdleConnTimeout = 90 * time.Second
+	return transport
+}
@@ -0,0 +1,89 @@
+package goplaces
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDefaultTransport(t *testing.T) {
+	transport := DefaultTransport()
+	if transport.MaxIdleConnsPerHost != defaultMaxIdleConnsPerHost {
+		t.Fatalf("unexpected MaxIdleConnsPerHost: %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport == http.DefaultTransport {
+		t.Fatalf("expected a clone of http.DefaultTransport")
+	}
+
+	client := NewClient(Options{APIKey: "test-key"})
+	if client.httpClient.Transport == nil {
+		t.Fatalf("expected default transport on client")
+	}
+
+	custom := DefaultTransport()
+	custom.DisableKeepAlives = true
+	client = NewClient(Options{APIKey: "test-key", Transport: custom})
+	if client.httpClient.Transport != custom {
+		t.Fatalf("expected custom transport on client")
+	}
+}
+
+func TestDefaultClientReusesConnections(t *testing.T) {
+	server, conns := newCountingServer()
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	for i := 0; i < 5; i++ {
+		if _, err := client.doRequest(context.Background(), http.MethodGet, server.URL, nil, ""); err != nil {
+			t.Fatalf("request error: %v", err)
+		}
+	}
+	if got := conns.Load(); got != 1 {
+		t.Fatalf("expected 1 connection, got %d", got)
+	}
+}
+
+func BenchmarkDoRequest(b *testing.B) {
+	noKeepAlive := DefaultTransport()
+	noKeepAlive.DisableKeepAlives = true
+	cases := []struct {
+		name      string
+		transport *http.Transport
+	}{
+		{"KeepAlive", DefaultTransport()},
+		{"NoKeepAlive", noKeepAlive},
+	}
+	for _, tc := range cases {
+		b.Run(tc.name, func(b *testing.B) {
+			server, conns := newCountingServer()
+			defer server.Close()
+			defer tc.transport.CloseIdleConnections()
+
+			client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, Transport: tc.transport})
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := client.doRequest(context.Background(), http.MethodGet, server.URL, nil, ""); err != nil {
+					b.Fatalf("request error: %v", err)
+				}
+			}
+			b.ReportMetric(float64(conns.Load())/float64(b.N), "conns/op")
+		})
+	}
+}
+
+func newCountingServer() (*httptest.Server, *atomic.Int64) {
+	var conns atomic.Int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	return server, &conns
+}
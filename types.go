@@ -9,22 +9,57 @@ type SearchRequest struct {
 	PageToken    string        `json:"page_token,omitempty"`
 	Language     string        `json:"language,omitempty"`
 	Region       string        `json:"region,omitempty"`
+	// PageSize caps results per API call. When smaller than Limit, Search
+	// follows page tokens until Limit results are gathered.
+	PageSize int `json:"page_size,omitempty"`
+	// RankPreference is RankPreferenceRelevance (API default) or
+	// RankPreferenceDistance, which requires LocationBias.
+	RankPreference string `json:"rank_preference,omitempty"`
+	// StrictTypeFiltering limits results to places whose type exactly
+	// matches the included type (Filters.Types[0]).
+	StrictTypeFiltering bool `json:"strict_type_filtering,omitempty"`
+	// Fields replaces the default field mask (Place field names such as
+	// "displayName", "rating"; id is always requested). Only fields that
+	// PlaceSummary surfaces are accepted.
+	Fields []string `json:"fields,omitempty"`
+	// KeywordMode is KeywordModeAppend (default: Filters.Keyword is added to
+	// the query) or KeywordModeSeparate (Filters.Keyword is left untouched
+	// for the caller's own use).
+	KeywordMode string `json:"keyword_mode,omitempty"`
 }
 
 // Filters are optional search refinements.
 type Filters struct {
-	Keyword     string   `json:"keyword,omitempty"`
+	Keyword string `json:"keyword,omitempty"`
+	// Types holds at most one value: text search has a single includedType.
 	Types       []string `json:"types,omitempty"`
 	OpenNow     *bool    `json:"open_now,omitempty"`
 	MinRating   *float64 `json:"min_rating,omitempty"`
 	PriceLevels []int    `json:"price_levels,omitempty"`
 }
 
-// LocationBias limits search results to a circular area.
+// LocationBias limits search results to a circular area, or to Rectangle
+// when set (search and autocomplete only).
 type LocationBias struct {
-	Lat     float64 `json:"lat"`
-	Lng     float64 `json:"lng"`
-	RadiusM float64 `json:"radius_m"`
+	Lat       float64      `json:"lat"`
+	Lng       float64      `json:"lng"`
+	RadiusM   float64      `json:"radius_m"`
+	Rectangle *BoundingBox `json:"rectangle,omitempty"`
+}
+
+// BoundingBox is a rectangle from its south-west to north-east corner.
+// SW.Lng may exceed NE.Lng for boxes crossing the antimeridian.
+type BoundingBox struct {
+	SW LatLng `json:"sw"`
+	NE LatLng `json:"ne"`
+}
+
+// PriceRange is the price span the API reports for a place, in
+// CurrencyCode units. End is nil for open-ended ranges ("$100 and up").
+type PriceRange struct {
+	CurrencyCode string   `json:"currency_code,omitempty"`
+	Start        *float64 `json:"start,omitempty"`
+	End          *float64 `json:"end,omitempty"`
 }
 
 // LatLng holds geographic coordinates.
@@ -47,11 +82,23 @@ type AutocompleteRequest struct {
 	Language     string        `json:"language,omitempty"`
 	Region       string        `json:"region,omitempty"`
 	LocationBias *LocationBias `json:"location_bias,omitempty"`
+	// IncludedPrimaryTypes restricts predictions to places whose primary
+	// type is one of these (up to 5), or to a collection like "(cities)".
+	IncludedPrimaryTypes []string `json:"included_primary_types,omitempty"`
+	// InputOffset is the cursor position in Input, counted in Unicode
+	// characters. Google uses the full input when it is nil.
+	InputOffset *int `json:"input_offset,omitempty"`
+	// Origin is the point Google measures each place suggestion's
+	// DistanceMeters from. Without it, no distances are returned.
+	Origin *LatLng `json:"origin,omitempty"`
 }
 
 // AutocompleteResponse contains suggestions from autocomplete.
 type AutocompleteResponse struct {
 	Suggestions []AutocompleteSuggestion `json:"suggestions"`
+	// SessionToken is the token sent with the request, including one
+	// generated by Options.AutoSessionToken. Reuse it to continue the session.
+	SessionToken string `json:"session_token,omitempty"`
 }
 
 // AutocompleteSuggestion is a place or query prediction.
@@ -72,8 +119,20 @@ type NearbySearchRequest struct {
 	Limit               int           `json:"limit,omitempty"`
 	IncludedTypes       []string      `json:"included_types,omitempty"`
 	ExcludedTypes       []string      `json:"excluded_types,omitempty"`
-	Language            string        `json:"language,omitempty"`
-	Region              string        `json:"region,omitempty"`
+	// IncludedPrimaryTypes matches only a place's primary type, so a
+	// gas station with a convenience store is not a "convenience_store".
+	IncludedPrimaryTypes []string `json:"included_primary_types,omitempty"`
+	// ExcludedPrimaryTypes drops places whose primary type is one of these.
+	ExcludedPrimaryTypes []string `json:"excluded_primary_types,omitempty"`
+	Language             string   `json:"language,omitempty"`
+	Region               string   `json:"region,omitempty"`
+	// MinRating drops results below this rating after the response arrives.
+	// Nearby search has no server-side rating filter, so fewer than Limit
+	// results may be returned.
+	MinRating *float64 `json:"min_rating,omitempty"`
+	// RankPreference is RankPreferencePopularity or RankPreferenceDistance;
+	// empty leaves the API default.
+	RankPreference string `json:"rank_preference,omitempty"`
 }
 
 // NearbySearchResponse contains nearby search results.
@@ -84,31 +143,159 @@ type NearbySearchResponse struct {
 
 // PlaceSummary is a compact view of a place.
 type PlaceSummary struct {
-	PlaceID    string   `json:"place_id"`
-	Name       string   `json:"name,omitempty"`
-	Address    string   `json:"address,omitempty"`
-	Location   *LatLng  `json:"location,omitempty"`
-	Rating     *float64 `json:"rating,omitempty"`
-	PriceLevel *int     `json:"price_level,omitempty"`
-	Types      []string `json:"types,omitempty"`
-	OpenNow    *bool    `json:"open_now,omitempty"`
+	PlaceID string `json:"place_id"`
+	Name    string `json:"name,omitempty"`
+	// NameLanguage is the language of Name, which may differ from the
+	// requested language when no translation exists.
+	NameLanguage    string   `json:"name_language,omitempty"`
+	Address         string   `json:"address,omitempty"`
+	Location        *LatLng  `json:"location,omitempty"`
+	Rating          *float64 `json:"rating,omitempty"`
+	UserRatingCount *int     `json:"user_rating_count,omitempty"`
+	PriceLevel      *int     `json:"price_level,omitempty"`
+	// PriceRange is more precise than PriceLevel, when the API has one.
+	PriceRange *PriceRange `json:"price_range,omitempty"`
+	Types      []string    `json:"types,omitempty"`
+	OpenNow    *bool       `json:"open_now,omitempty"`
+	// BusinessStatus is OPERATIONAL, CLOSED_TEMPORARILY, or CLOSED_PERMANENTLY.
+	BusinessStatus string `json:"business_status,omitempty"`
 }
 
+// Business status values reported by the Places API.
+const (
+	BusinessStatusOperational       = "OPERATIONAL"
+	BusinessStatusClosedTemporarily = "CLOSED_TEMPORARILY"
+	BusinessStatusClosedPermanently = "CLOSED_PERMANENTLY"
+)
+
 // PlaceDetails is a detailed view of a place.
 type PlaceDetails struct {
-	PlaceID    string   `json:"place_id"`
-	Name       string   `json:"name,omitempty"`
-	Address    string   `json:"address,omitempty"`
-	Location   *LatLng  `json:"location,omitempty"`
-	Rating     *float64 `json:"rating,omitempty"`
-	PriceLevel *int     `json:"price_level,omitempty"`
-	Types      []string `json:"types,omitempty"`
-	Phone      string   `json:"phone,omitempty"`
-	Website    string   `json:"website,omitempty"`
-	Hours      []string `json:"hours,omitempty"`
-	OpenNow    *bool    `json:"open_now,omitempty"`
-	Reviews    []Review `json:"reviews,omitempty"`
-	Photos     []Photo  `json:"photos,omitempty"`
+	PlaceID      string `json:"place_id"`
+	Name         string `json:"name,omitempty"`
+	NameLanguage string `json:"name_language,omitempty"`
+	Address      string `json:"address,omitempty"`
+	// ShortAddress is set when DetailsRequest.IncludeShortAddress is true.
+	ShortAddress    string   `json:"short_address,omitempty"`
+	Location        *LatLng  `json:"location,omitempty"`
+	Rating          *float64 `json:"rating,omitempty"`
+	UserRatingCount *int     `json:"user_rating_count,omitempty"`
+	PriceLevel      *int     `json:"price_level,omitempty"`
+	// PriceRange is more precise than PriceLevel, when the API has one.
+	PriceRange         *PriceRange `json:"price_range,omitempty"`
+	Types              []string    `json:"types,omitempty"`
+	Phone              string      `json:"phone,omitempty"`
+	InternationalPhone string      `json:"international_phone,omitempty"`
+	Website            string      `json:"website,omitempty"`
+	Hours              []string    `json:"hours,omitempty"`
+	// Periods are the regular weekly opening hours in the place's local time.
+	Periods        []Period `json:"periods,omitempty"`
+	OpenNow        *bool    `json:"open_now,omitempty"`
+	BusinessStatus string   `json:"business_status,omitempty"`
+	// UTCOffsetMinutes is the place's current offset from UTC.
+	UTCOffsetMinutes *int     `json:"utc_offset_minutes,omitempty"`
+	Reviews          []Review `json:"reviews,omitempty"`
+	Photos           []Photo  `json:"photos,omitempty"`
+	// Services is set when DetailsRequest.IncludeServices is true and the
+	// API returned at least one option.
+	Services *ServiceOptions `json:"services,omitempty"`
+	// Accessibility is set when DetailsRequest.IncludeAccessibility is true
+	// and the API returned at least one option.
+	Accessibility *AccessibilityOptions `json:"accessibility,omitempty"`
+	// Amenities is set when DetailsRequest.IncludeAmenities is true and the
+	// API returned at least one attribute.
+	Amenities *Amenities `json:"amenities,omitempty"`
+	// AddressComponents is set when DetailsRequest.IncludeAddressComponents is true.
+	AddressComponents []AddressComponent `json:"address_components,omitempty"`
+	// Viewport frames the place on a map (API low/high as SW/NE); set when
+	// DetailsRequest.IncludeViewport is true.
+	Viewport *BoundingBox `json:"viewport,omitempty"`
+	PlusCode *PlusCode    `json:"plus_code,omitempty"`
+	// EVChargeOptions is set when DetailsRequest.IncludeEV is true and the
+	// place has EV chargers.
+	EVChargeOptions *EVChargeOptions `json:"ev_charge_options,omitempty"`
+}
+
+// EVChargeOptions describes the EV chargers at a place.
+type EVChargeOptions struct {
+	ConnectorCount int           `json:"connector_count,omitempty"`
+	Connectors     []EVConnector `json:"connectors,omitempty"`
+}
+
+// EVConnector aggregates the connectors of one type and charge rate. Type is
+// the API enum (e.g. EV_CONNECTOR_TYPE_CCS_COMBO_1). Availability counts are
+// nil when the operator does not report them.
+type EVConnector struct {
+	Type                       string   `json:"type,omitempty"`
+	MaxChargeRateKW            *float64 `json:"max_charge_rate_kw,omitempty"`
+	Count                      int      `json:"count,omitempty"`
+	AvailableCount             *int     `json:"available_count,omitempty"`
+	OutOfServiceCount          *int     `json:"out_of_service_count,omitempty"`
+	AvailabilityLastUpdateTime string   `json:"availability_last_update_time,omitempty"`
+}
+
+// Period is one opening interval. Close is nil when the place never closes
+// (the API's encoding of "open 24 hours, every day").
+type Period struct {
+	Open  TimeOfWeek  `json:"open"`
+	Close *TimeOfWeek `json:"close,omitempty"`
+}
+
+// TimeOfWeek is a local wall-clock time. Day is 0 (Sunday) to 6 (Saturday).
+type TimeOfWeek struct {
+	Day    int `json:"day"`
+	Hour   int `json:"hour"`
+	Minute int `json:"minute"`
+}
+
+// PlusCode is an Open Location Code for a place: the global code
+// ("849VCWC8+R9") and the short code with a locality ("CWC8+R9 Seattle, WA").
+type PlusCode struct {
+	GlobalCode   string `json:"global_code,omitempty"`
+	CompoundCode string `json:"compound_code,omitempty"`
+}
+
+// AddressComponent is one structured part of a place address (street
+// number, locality, postal code, ...).
+type AddressComponent struct {
+	LongText  string   `json:"long_text,omitempty"`
+	ShortText string   `json:"short_text,omitempty"`
+	Types     []string `json:"types,omitempty"`
+}
+
+// ServiceOptions reports how a place serves customers. Nil fields are
+// unknown.
+type ServiceOptions struct {
+	DineIn         *bool `json:"dine_in,omitempty"`
+	Takeout        *bool `json:"takeout,omitempty"`
+	Delivery       *bool `json:"delivery,omitempty"`
+	CurbsidePickup *bool `json:"curbside_pickup,omitempty"`
+	Reservable     *bool `json:"reservable,omitempty"`
+}
+
+// Amenities reports what a place serves and offers. Nil fields are unknown.
+// Reservations and curbside pickup are in ServiceOptions.
+type Amenities struct {
+	ServesBeer           *bool `json:"serves_beer,omitempty"`
+	ServesWine           *bool `json:"serves_wine,omitempty"`
+	ServesCocktails      *bool `json:"serves_cocktails,omitempty"`
+	ServesBreakfast      *bool `json:"serves_breakfast,omitempty"`
+	ServesBrunch         *bool `json:"serves_brunch,omitempty"`
+	ServesLunch          *bool `json:"serves_lunch,omitempty"`
+	ServesDinner         *bool `json:"serves_dinner,omitempty"`
+	ServesVegetarianFood *bool `json:"serves_vegetarian_food,omitempty"`
+	OutdoorSeating       *bool `json:"outdoor_seating,omitempty"`
+	LiveMusic            *bool `json:"live_music,omitempty"`
+	MenuForChildren      *bool `json:"menu_for_children,omitempty"`
+	AllowsDogs           *bool `json:"allows_dogs,omitempty"`
+}
+
+// AccessibilityOptions reports wheelchair accessibility. Nil fields are
+// unknown.
+type AccessibilityOptions struct {
+	WheelchairAccessibleParking  *bool `json:"wheelchair_accessible_parking,omitempty"`
+	WheelchairAccessibleEntrance *bool `json:"wheelchair_accessible_entrance,omitempty"`
+	WheelchairAccessibleRestroom *bool `json:"wheelchair_accessible_restroom,omitempty"`
+	WheelchairAccessibleSeating  *bool `json:"wheelchair_accessible_seating,omitempty"`
 }
 
 // LocationResolveRequest resolves a text location into place candidates.
@@ -128,6 +315,23 @@ type DetailsRequest struct {
 	IncludeReviews bool `json:"include_reviews,omitempty"`
 	// IncludePhotos requests the photos field in Place Details.
 	IncludePhotos bool `json:"include_photos,omitempty"`
+	// IncludeServices requests dine-in/takeout/delivery/curbside/reservable.
+	IncludeServices bool `json:"include_services,omitempty"`
+	// IncludeAddressComponents requests structured address parts.
+	IncludeAddressComponents bool `json:"include_address_components,omitempty"`
+	// IncludeViewport requests the place's map viewport.
+	IncludeViewport bool `json:"include_viewport,omitempty"`
+	// IncludeShortAddress requests shortFormattedAddress.
+	IncludeShortAddress bool `json:"include_short_address,omitempty"`
+	// IncludeEV requests EV charging options.
+	IncludeEV bool `json:"include_ev,omitempty"`
+	// IncludeAccessibility requests wheelchair accessibility options.
+	IncludeAccessibility bool `json:"include_accessibility,omitempty"`
+	// IncludeAmenities requests food, drink, seating, and pet attributes.
+	IncludeAmenities bool `json:"include_amenities,omitempty"`
+	// Fields replaces the base field mask (Place field names; id is always
+	// requested). Include* options still add their fields.
+	Fields []string `json:"fields,omitempty"`
 }
 
 // Review represents a user review of a place.
@@ -185,6 +389,14 @@ type PhotoMediaResponse struct {
 	PhotoURI string `json:"photo_uri,omitempty"`
 }
 
+// PhotoDownload describes bytes streamed by PhotoBytes.
+type PhotoDownload struct {
+	Name        string `json:"name,omitempty"`
+	PhotoURI    string `json:"photo_uri,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Bytes       int64  `json:"bytes"`
+}
+
 // LocationResolveResponse contains resolved locations.
 type LocationResolveResponse struct {
 	Results []ResolvedLocation `json:"results"`
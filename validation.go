@@ -1,9 +1,51 @@
 package goplaces
 
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// languagePattern is a loose BCP-47 shape: a 2-3 letter language plus
+	// optional subtags (en, en-US, zh-Hant-TW, es-419).
+	languagePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+	// regionPattern is a two-letter CLDR region code (US, DE).
+	regionPattern = regexp.MustCompile(`^[A-Za-z]{2}$`)
+)
+
+// validateLocale catches obvious language/region mistakes ("english",
+// "en_US", "USA") before they reach the API, which ignores or opaquely
+// rejects them. Empty values are allowed.
+func validateLocale(language, region string) error {
+	language = strings.TrimSpace(language)
+	if language != "" && !languagePattern.MatchString(language) {
+		message := "must be a BCP-47 language code like en or en-US"
+		if strings.Contains(language, "_") {
+			message += fmt.Sprintf(" (use %q)", strings.ReplaceAll(language, "_", "-"))
+		}
+		return ValidationError{Field: "language", Message: fmt.Sprintf("%s: got %q", message, language)}
+	}
+	region = strings.TrimSpace(region)
+	if region != "" && !regionPattern.MatchString(region) {
+		return ValidationError{
+			Field:   "region",
+			Message: fmt.Sprintf("must be a two-letter CLDR region code like US or DE: got %q", region),
+		}
+	}
+	return nil
+}
+
 func validateLocationBias(bias *LocationBias) error {
 	if bias == nil {
 		return nil
 	}
+	if bias.Rectangle != nil {
+		if bias.Lat != 0 || bias.Lng != 0 || bias.RadiusM != 0 {
+			return ValidationError{Field: "location_bias", Message: "set either a circle or a rectangle"}
+		}
+		return validateBoundingBox("location_bias.rectangle", bias.Rectangle)
+	}
 	if bias.RadiusM <= 0 {
 		return ValidationError{Field: "location_bias.radius_m", Message: "must be > 0"}
 	}
@@ -15,3 +57,32 @@ func validateLocationBias(bias *LocationBias) error {
 	}
 	return nil
 }
+
+func validateLatLng(field string, point LatLng) error {
+	if point.Lat < -90 || point.Lat > 90 {
+		return ValidationError{Field: field + ".lat", Message: "must be -90..90"}
+	}
+	if point.Lng < -180 || point.Lng > 180 {
+		return ValidationError{Field: field + ".lng", Message: "must be -180..180"}
+	}
+	return nil
+}
+
+func validateBoundingBox(field string, box *BoundingBox) error {
+	corners := []struct {
+		name  string
+		point LatLng
+	}{{"sw", box.SW}, {"ne", box.NE}}
+	for _, corner := range corners {
+		if corner.point.Lat < -90 || corner.point.Lat > 90 {
+			return ValidationError{Field: field + "." + corner.name + ".lat", Message: "must be -90..90"}
+		}
+		if corner.point.Lng < -180 || corner.point.Lng > 180 {
+			return ValidationError{Field: field + "." + corner.name + ".lng", Message: "must be -180..180"}
+		}
+	}
+	if box.SW.Lat > box.NE.Lat {
+		return ValidationError{Field: field, Message: "sw.lat must be <= ne.lat"}
+	}
+	return nil
+}